@@ -27,7 +27,7 @@ func Project(host, username, password string) error {
 	if err != nil {
 		return fmt.Errorf("get client set error:%v", err)
 	}
-	result, err := clientSet.V2.Get("15")
+	result, err := clientSet.V2.GetByID(15)
 	if err != nil || len(result.Name) == 0 {
 		return fmt.Errorf("%v", err)
 	}
@@ -44,7 +44,7 @@ func Project(host, username, password string) error {
 		return fmt.Errorf("%v", err)
 	}
 
-	err = clientSet.V2.Delete("11")
+	err = clientSet.V2.DeleteByID(11)
 	if err != nil || len(*result1) == 0 {
 		return fmt.Errorf("%v", err)
 	}
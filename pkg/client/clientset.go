@@ -17,10 +17,19 @@ package client
 
 import (
 	"fmt"
+	"github.com/TimeBye/go-harbor/pkg/gc"
+	"github.com/TimeBye/go-harbor/pkg/label"
 	project2 "github.com/TimeBye/go-harbor/pkg/project"
+	"github.com/TimeBye/go-harbor/pkg/registry"
+	"github.com/TimeBye/go-harbor/pkg/replication"
 	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
 	flowcontrol2 "github.com/TimeBye/go-harbor/pkg/rest/util/flowcontrol"
+	"github.com/TimeBye/go-harbor/pkg/retention"
+	"github.com/TimeBye/go-harbor/pkg/robot"
+	"github.com/TimeBye/go-harbor/pkg/scanner"
+	"github.com/TimeBye/go-harbor/pkg/system"
 	"github.com/TimeBye/go-harbor/pkg/user"
+	"github.com/TimeBye/go-harbor/pkg/usergroup"
 )
 
 type Interface interface {
@@ -30,8 +39,18 @@ type Interface interface {
 // Clientset contains the clients for groups. Each group has exactly one
 // version included in a Clientset.
 type Clientset struct {
-	V2   *project2.ProjectsV2Client
-	User *user.UsersClient
+	V2          *project2.ProjectsV2Client
+	User        *user.UsersClient
+	Label       *label.LabelsClient
+	UserGroup   *usergroup.UserGroupsClient
+	Robot       *robot.RobotsClient
+	Registry    *registry.RegistriesClient
+	Replication *replication.ReplicationClient
+	Scanner     *scanner.ScannersClient
+	ScanAll     *scanner.ScanAllClient
+	GC          *gc.GCClient
+	Retention   *retention.RetentionClient
+	System      *system.SystemClient
 }
 
 func NewForConfig(c *rest2.Config) (*Clientset, error) {
@@ -52,5 +71,45 @@ func NewForConfig(c *rest2.Config) (*Clientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	cs.Label, err = label.NewLabelsClient(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.UserGroup, err = usergroup.NewUserGroupsClient(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.Robot, err = robot.NewRobotsClient(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.Registry, err = registry.NewRegistriesClient(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.Replication, err = replication.NewReplicationClient(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.Scanner, err = scanner.NewScannersClient(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.ScanAll, err = scanner.NewScanAllClient(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.GC, err = gc.NewGCClient(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.Retention, err = retention.NewRetentionClient(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.System, err = system.NewSystemClient(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 	return cs, nil
 }
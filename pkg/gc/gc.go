@@ -0,0 +1,194 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package gc
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
+)
+
+// GCInterface runs, schedules and follows the garbage collection of the registry.
+type GCInterface interface {
+	Trigger(params *model.GCParameters) (id int64, err error)
+	Schedule() (result *model.Schedule, err error)
+	SetSchedule(schedule *model.ScheduleObj, params *model.GCParameters) (err error)
+	History(query *model.Query) (results *[]model.GCHistory, err error)
+	HistoryPager(ctx context.Context, query *model.Query) *rest2.Pager[model.GCHistory]
+	Get(id int64) (result *model.GCHistory, err error)
+	Stop(id int64) (err error)
+	Log(id int64) (log string, err error)
+	TriggerContext(ctx context.Context, params *model.GCParameters) (id int64, err error)
+	ScheduleContext(ctx context.Context) (result *model.Schedule, err error)
+	SetScheduleContext(ctx context.Context, schedule *model.ScheduleObj, params *model.GCParameters) (err error)
+	HistoryContext(ctx context.Context, query *model.Query) (results *[]model.GCHistory, err error)
+	GetContext(ctx context.Context, id int64) (result *model.GCHistory, err error)
+	StopContext(ctx context.Context, id int64) (err error)
+	LogContext(ctx context.Context, id int64) (log string, err error)
+}
+
+var _ GCInterface = &GCClient{}
+
+type GCClient struct {
+	restClient rest2.Interface
+}
+
+func NewGCClient(restClient *rest2.Config) (*GCClient, error) {
+	client, err := rest2.RESTClientFor(restClient)
+	if err != nil {
+		return nil, err
+	}
+	return &GCClient{restClient: client}, nil
+}
+
+// gc points req at /system/gc.
+func gc(req *rest2.Request) *rest2.Request {
+	return req.Resource("system").Name("gc")
+}
+
+// Trigger starts a garbage collection now and returns the ID of the run. Set
+// params.DryRun to only report what would be deleted, see model.ParseGCLog.
+func (g *GCClient) Trigger(params *model.GCParameters) (id int64, err error) {
+	return g.TriggerContext(context.Background(), params)
+}
+
+// TriggerContext is like Trigger but binds the request to ctx.
+func (g *GCClient) TriggerContext(ctx context.Context, params *model.GCParameters) (id int64, err error) {
+	return gc(g.restClient.Post().Context(ctx)).
+		Suffix("schedule").
+		Body(&model.Schedule{
+			Schedule:   &model.ScheduleObj{Type: model.ScheduleTypeManual},
+			Parameters: params.Map(),
+		}).
+		Do().
+		CreatedID()
+}
+
+// Schedule returns the schedule of the garbage collection, with an ID of 0 if it was
+// never scheduled.
+func (g *GCClient) Schedule() (result *model.Schedule, err error) {
+	return g.ScheduleContext(context.Background())
+}
+
+// ScheduleContext is like Schedule but binds the request to ctx.
+func (g *GCClient) ScheduleContext(ctx context.Context) (result *model.Schedule, err error) {
+	result = &model.Schedule{}
+	err = gc(g.restClient.Get().Context(ctx)).
+		Suffix("schedule").
+		Do().
+		Into(result)
+	return
+}
+
+// SetSchedule schedules the garbage collection with params, e.g. with
+// model.CronSchedule, or unschedules it with model.NoSchedule. The schedule is
+// created or updated as needed, Manual schedules are rejected: use Trigger.
+func (g *GCClient) SetSchedule(schedule *model.ScheduleObj, params *model.GCParameters) (err error) {
+	return g.SetScheduleContext(context.Background(), schedule, params)
+}
+
+// SetScheduleContext is like SetSchedule but binds the requests to ctx.
+func (g *GCClient) SetScheduleContext(ctx context.Context, schedule *model.ScheduleObj, params *model.GCParameters) (err error) {
+	if schedule.Type == model.ScheduleTypeManual {
+		return fmt.Errorf("a %s schedule cannot be set, use Trigger to run the garbage collection now", model.ScheduleTypeManual)
+	}
+	existing, err := g.ScheduleContext(ctx)
+	if err != nil {
+		return err
+	}
+	req := g.restClient.Post()
+	if existing.ID != 0 {
+		req = g.restClient.Put()
+	}
+	return gc(req.Context(ctx)).
+		Suffix("schedule").
+		Body(&model.Schedule{Schedule: schedule, Parameters: params.Map()}).
+		Do().
+		Error()
+}
+
+// History lists the garbage collection runs, latest first.
+func (g *GCClient) History(query *model.Query) (results *[]model.GCHistory, err error) {
+	return g.HistoryContext(context.Background(), query)
+}
+
+// HistoryContext is like History but binds the request to ctx.
+func (g *GCClient) HistoryContext(ctx context.Context, query *model.Query) (results *[]model.GCHistory, err error) {
+	results = &[]model.GCHistory{}
+	err = gc(g.restClient.List().Context(ctx)).
+		Params(*query).
+		Do().
+		Into(results)
+	return
+}
+
+// HistoryPager returns a Pager walking every garbage collection run matching query.
+func (g *GCClient) HistoryPager(ctx context.Context, query *model.Query) *rest2.Pager[model.GCHistory] {
+	return rest2.NewPager[model.GCHistory](ctx, func() *rest2.Request {
+		return gc(g.restClient.List()).
+			Params(*query)
+	})
+}
+
+func (g *GCClient) Get(id int64) (result *model.GCHistory, err error) {
+	return g.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (g *GCClient) GetContext(ctx context.Context, id int64) (result *model.GCHistory, err error) {
+	result = &model.GCHistory{}
+	err = gc(g.restClient.Get().Context(ctx)).
+		Suffix(strconv.FormatInt(id, 10)).
+		Do().
+		Into(result)
+	return
+}
+
+// Stop stops the running garbage collection id.
+func (g *GCClient) Stop(id int64) (err error) {
+	return g.StopContext(context.Background(), id)
+}
+
+// StopContext is like Stop but binds the request to ctx.
+func (g *GCClient) StopContext(ctx context.Context, id int64) (err error) {
+	return gc(g.restClient.Put().Context(ctx)).
+		Suffix(strconv.FormatInt(id, 10)).
+		Do().
+		Error()
+}
+
+// Log returns the log of the garbage collection id.
+func (g *GCClient) Log(id int64) (log string, err error) {
+	return g.LogContext(context.Background(), id)
+}
+
+// LogContext is like Log but binds the request to ctx.
+func (g *GCClient) LogContext(ctx context.Context, id int64) (log string, err error) {
+	// Streamed so that long logs are not cut off by the timeout of the client.
+	stream, err := gc(g.restClient.Get().Context(ctx)).
+		Suffix(strconv.FormatInt(id, 10), "log").
+		Stream()
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+	body, err := ioutil.ReadAll(stream)
+	return string(body), err
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package gc
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	"github.com/TimeBye/go-harbor/pkg/rest/resttest"
+)
+
+func newTestClient(t *testing.T) (*GCClient, *resttest.Server) {
+	server := resttest.NewServer(t)
+	client, err := NewGCClient(server.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server
+}
+
+func TestGCTrigger(t *testing.T) {
+	client, server := newTestClient(t)
+	server.ReplyCreated(http.MethodPost, "/system/gc/schedule", "12")
+
+	id, err := client.Trigger(&model.GCParameters{DryRun: true, Workers: 2})
+	if err != nil || id != 12 {
+		t.Fatalf("unexpected id %d: %v", id, err)
+	}
+	want := `{"creation_time":"0001-01-01T00:00:00Z","update_time":"0001-01-01T00:00:00Z","schedule":{"type":"Manual"},"parameters":{"delete_untagged":false,"dry_run":true,"workers":2}}`
+	if body := string(server.Last().Body); body != want {
+		t.Errorf("unexpected body %s", body)
+	}
+}
+
+func TestGCSetSchedule(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		existing string
+		method   string
+	}{
+		{name: "never scheduled", existing: `{"schedule":null}`, method: http.MethodPost},
+		{name: "scheduled", existing: `{"id":2,"schedule":{"type":"Weekly","cron":"0 0 0 * * 0"}}`, method: http.MethodPut},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			client, server := newTestClient(t)
+			server.Reply(http.MethodGet, "/system/gc/schedule", http.StatusOK, c.existing)
+			server.Reply(c.method, "/system/gc/schedule", http.StatusOK, "")
+
+			if err := client.SetSchedule(model.CronSchedule("0 0 2 * * *"), &model.GCParameters{DeleteUntagged: true}); err != nil {
+				t.Fatal(err)
+			}
+			last := server.Last()
+			if last.Method != c.method {
+				t.Errorf("expected %s, got %s", c.method, last.Method)
+			}
+			schedule := &model.Schedule{}
+			last.Decode(t, schedule)
+			if schedule.Schedule == nil || schedule.Schedule.Cron != "0 0 2 * * *" || schedule.Parameters["delete_untagged"] != true {
+				t.Errorf("unexpected body %s", last.Body)
+			}
+		})
+	}
+}
+
+func TestGCSetScheduleRejectsManual(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodGet, "/system/gc/schedule", http.StatusOK, `{"id":2,"schedule":{"type":"Weekly","cron":"0 0 0 * * 0"}}`)
+
+	if err := client.SetSchedule(&model.ScheduleObj{Type: model.ScheduleTypeManual}, nil); err == nil {
+		t.Fatal("expected a Manual schedule to be rejected")
+	}
+	if requests := server.Requests(); len(requests) != 0 {
+		t.Errorf("expected no request, got %d", len(requests))
+	}
+}
+
+func TestGCLogOutlivesClientTimeout(t *testing.T) {
+	server := resttest.NewServer(t)
+	server.Handle(http.MethodGet, "/system/gc/12/log", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("2024-04-08T02:00:00Z [INFO] start to run gc in job.\n"))
+		w.(http.Flusher).Flush()
+		time.Sleep(300 * time.Millisecond)
+		w.Write([]byte("2024-04-08T02:00:01Z [INFO] success to run gc in job.\n"))
+	})
+	config := server.Config()
+	config.Timeout = 100 * time.Millisecond
+	client, err := NewGCClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	log, err := client.Log(12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if log != "2024-04-08T02:00:00Z [INFO] start to run gc in job.\n2024-04-08T02:00:01Z [INFO] success to run gc in job.\n" {
+		t.Errorf("unexpected log %q", log)
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package label
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
+	cmodels "github.com/goharbor/harbor/src/pkg/label/model"
+)
+
+// LabelsInterface manages global and project labels. Labels are attached to
+// artifacts through the artifact client.
+type LabelsInterface interface {
+	Get(id int64) (result *cmodels.Label, err error)
+	List(query *ListOptions) (result *[]cmodels.Label, err error)
+	ListPager(ctx context.Context, query *ListOptions) *rest2.Pager[cmodels.Label]
+	ListAll(query *ListOptions) (result *[]cmodels.Label, err error)
+	Create(label *cmodels.Label) (id int64, err error)
+	Update(id int64, label *cmodels.Label) (err error)
+	Delete(id int64) (err error)
+	GetContext(ctx context.Context, id int64) (result *cmodels.Label, err error)
+	ListContext(ctx context.Context, query *ListOptions) (result *[]cmodels.Label, err error)
+	ListAllContext(ctx context.Context, query *ListOptions) (result *[]cmodels.Label, err error)
+	CreateContext(ctx context.Context, label *cmodels.Label) (id int64, err error)
+	UpdateContext(ctx context.Context, id int64, label *cmodels.Label) (err error)
+	DeleteContext(ctx context.Context, id int64) (err error)
+}
+
+// ListOptions selects the labels to list. Scope is required by Harbor, ProjectID
+// too when Scope is model.LabelScopeProject.
+type ListOptions struct {
+	*model.Query
+	// Name The label name.
+	Name string `json:"name,omitempty"`
+	// Scope The label scope, model.LabelScopeGlobal or model.LabelScopeProject.
+	Scope string `json:"scope,omitempty"`
+	// ProjectID The project ID, required for project scoped labels.
+	ProjectID int64 `json:"project_id,omitempty"`
+}
+
+// GlobalLabels returns the options listing the global labels.
+func GlobalLabels() *ListOptions {
+	return &ListOptions{Query: &model.Query{}, Scope: model.LabelScopeGlobal}
+}
+
+// ProjectLabels returns the options listing the labels of the project projectID.
+func ProjectLabels(projectID int64) *ListOptions {
+	return &ListOptions{Query: &model.Query{}, Scope: model.LabelScopeProject, ProjectID: projectID}
+}
+
+type LabelsClient struct {
+	restClient rest2.Interface
+}
+
+func NewLabelsClient(restClient *rest2.Config) (*LabelsClient, error) {
+	client, err := rest2.RESTClientFor(restClient)
+	if err != nil {
+		return nil, err
+	}
+	return &LabelsClient{restClient: client}, nil
+}
+
+func (l *LabelsClient) Get(id int64) (result *cmodels.Label, err error) {
+	return l.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (l *LabelsClient) GetContext(ctx context.Context, id int64) (result *cmodels.Label, err error) {
+	result = &cmodels.Label{}
+	err = l.restClient.Get().
+		Context(ctx).
+		Resource("labels").
+		Name(strconv.FormatInt(id, 10)).
+		Do().
+		Into(result)
+	return
+}
+
+// List lists one page of the labels selected by query.
+func (l *LabelsClient) List(query *ListOptions) (result *[]cmodels.Label, err error) {
+	return l.ListContext(context.Background(), query)
+}
+
+// ListContext is like List but binds the request to ctx.
+func (l *LabelsClient) ListContext(ctx context.Context, query *ListOptions) (result *[]cmodels.Label, err error) {
+	result = &[]cmodels.Label{}
+	err = l.restClient.List().
+		Context(ctx).
+		Resource("labels").
+		Params(*query).
+		Do().
+		Into(result)
+	return
+}
+
+// ListPager returns a Pager walking every label selected by query.
+func (l *LabelsClient) ListPager(ctx context.Context, query *ListOptions) *rest2.Pager[cmodels.Label] {
+	return rest2.NewPager[cmodels.Label](ctx, func() *rest2.Request {
+		return l.restClient.List().
+			Resource("labels").
+			Params(*query)
+	})
+}
+
+// ListAll returns every label selected by query, walking all pages.
+func (l *LabelsClient) ListAll(query *ListOptions) (result *[]cmodels.Label, err error) {
+	return l.ListAllContext(context.Background(), query)
+}
+
+// ListAllContext is like ListAll but binds the requests to ctx.
+func (l *LabelsClient) ListAllContext(ctx context.Context, query *ListOptions) (result *[]cmodels.Label, err error) {
+	items, err := l.ListPager(ctx, query).All()
+	return &items, err
+}
+
+// Create creates a label and returns its ID. label.Scope decides whether the label
+// is global or belongs to the project label.ProjectID.
+func (l *LabelsClient) Create(label *cmodels.Label) (id int64, err error) {
+	return l.CreateContext(context.Background(), label)
+}
+
+// CreateContext is like Create but binds the request to ctx.
+func (l *LabelsClient) CreateContext(ctx context.Context, label *cmodels.Label) (id int64, err error) {
+	return l.restClient.Post().
+		Context(ctx).
+		Resource("labels").
+		Body(label).
+		Do().
+		CreatedID()
+}
+
+// Update changes the name, description or color of the label id.
+func (l *LabelsClient) Update(id int64, label *cmodels.Label) (err error) {
+	return l.UpdateContext(context.Background(), id, label)
+}
+
+// UpdateContext is like Update but binds the request to ctx.
+func (l *LabelsClient) UpdateContext(ctx context.Context, id int64, label *cmodels.Label) (err error) {
+	return l.restClient.Put().
+		Context(ctx).
+		Resource("labels").
+		Name(strconv.FormatInt(id, 10)).
+		Body(label).
+		Do().
+		Error()
+}
+
+// Delete deletes the label id, detaching it from every artifact.
+func (l *LabelsClient) Delete(id int64) (err error) {
+	return l.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext is like Delete but binds the request to ctx.
+func (l *LabelsClient) DeleteContext(ctx context.Context, id int64) (err error) {
+	return l.restClient.Delete().
+		Context(ctx).
+		Resource("labels").
+		Name(strconv.FormatInt(id, 10)).
+		Do().
+		Error()
+}
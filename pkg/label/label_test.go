@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package label
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	"github.com/TimeBye/go-harbor/pkg/rest/resttest"
+	cmodels "github.com/goharbor/harbor/src/pkg/label/model"
+)
+
+func newTestClient(t *testing.T) (*LabelsClient, *resttest.Server) {
+	server := resttest.NewServer(t)
+	client, err := NewLabelsClient(server.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server
+}
+
+func TestLabelsListScopes(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodGet, "/labels", http.StatusOK, `[{"id":1,"name":"release","scope":"g"}]`)
+
+	result, err := client.List(GlobalLabels())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list := *result; len(list) != 1 || list[0].Name != "release" || list[0].Scope != model.LabelScopeGlobal {
+		t.Errorf("unexpected labels %+v", list)
+	}
+	query := server.Last().Query
+	if query.Get("scope") != "g" || query.Has("project_id") {
+		t.Errorf("unexpected global query %v", query)
+	}
+
+	if _, err := client.List(ProjectLabels(7)); err != nil {
+		t.Fatal(err)
+	}
+	query = server.Last().Query
+	if query.Get("scope") != "p" || query.Get("project_id") != "7" {
+		t.Errorf("unexpected project query %v", query)
+	}
+}
+
+func TestLabelsCRUD(t *testing.T) {
+	client, server := newTestClient(t)
+	server.ReplyCreated(http.MethodPost, "/labels", "3")
+	id, err := client.Create(&cmodels.Label{Name: "qa", Scope: model.LabelScopeProject, ProjectID: 7})
+	if err != nil || id != 3 {
+		t.Fatalf("expected ID 3, got %d, %v", id, err)
+	}
+	created := map[string]interface{}{}
+	server.Last().Decode(t, &created)
+	if created["name"] != "qa" || created["scope"] != "p" || created["project_id"] != float64(7) {
+		t.Errorf("unexpected creation body %v", created)
+	}
+
+	server.Reply(http.MethodPut, "/labels/3", http.StatusOK, "")
+	if err := client.Update(3, &cmodels.Label{Name: "qa", Color: "#FF0000"}); err != nil {
+		t.Fatal(err)
+	}
+	server.Reply(http.MethodDelete, "/labels/3", http.StatusOK, "")
+	if err := client.Delete(3); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// Types of the accessories Harbor links to an artifact.
+const (
+	AccessoryTypeCosignSignature   = "signature.cosign"
+	AccessoryTypeNotationSignature = "signature.notation"
+	AccessoryTypeNydusAccelerator  = "accelerator.nydus"
+	AccessoryTypeHarborSBOM        = "harbor.sbom"
+	AccessoryTypeSubject           = "subject.accessory"
+)
+
+// Accessory is an artifact linked to a subject artifact, such as a signature, an
+// SBOM or an attestation.
+type Accessory struct {
+	ID                    int64     `json:"id"`
+	ArtifactID            int64     `json:"artifact_id"`
+	SubjectArtifactID     int64     `json:"subject_artifact_id"`
+	SubjectArtifactRepo   string    `json:"subject_artifact_repo"`
+	SubjectArtifactDigest string    `json:"subject_artifact_digest"`
+	Type                  string    `json:"type"`
+	Size                  int64     `json:"size"`
+	Digest                string    `json:"digest"`
+	CreationTime          time.Time `json:"creation_time"`
+	Icon                  string    `json:"icon"`
+}
+
+// IsSignature tells whether the accessory is a signature of its subject artifact.
+func (a *Accessory) IsSignature() bool {
+	return strings.HasPrefix(a.Type, "signature.")
+}
+
+// MediaTypeOCIImageIndex is the media type of the index returned by the OCI referrers API.
+const MediaTypeOCIImageIndex = "application/vnd.oci.image.index.v1+json"
+
+// ReferrersIndex lists the manifests referring to a subject manifest through the
+// OCI distribution referrers API.
+type ReferrersIndex struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType"`
+	Manifests     []OCIDescriptor `json:"manifests"`
+}
+
+// OCIDescriptor describes a manifest referring to a subject manifest.
+type OCIDescriptor struct {
+	MediaType    string            `json:"mediaType"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Kinds of the additions of an artifact, the keys of Artifact.AdditionLinks.
+const (
+	AdditionTypeBuildHistory    = "build_history"
+	AdditionTypeValuesYAML      = "values.yaml"
+	AdditionTypeReadme          = "readme.md"
+	AdditionTypeDependencies    = "dependencies"
+	AdditionTypeVulnerabilities = "vulnerabilities"
+)
+
+// Addition is an extra resource of an artifact, such as the build history of an
+// image or the README of a chart. Content is kept raw, the decoders below turn
+// it into typed values according to Type.
+type Addition struct {
+	Type        string
+	ContentType string
+	Content     []byte
+}
+
+// String returns the content of the addition as text, whatever its kind.
+func (a *Addition) String() string {
+	return string(a.Content)
+}
+
+func (a *Addition) decode(kind string, obj interface{}) error {
+	if a.Type != kind {
+		return fmt.Errorf("cannot decode a %q addition as %q", a.Type, kind)
+	}
+	return json.Unmarshal(a.Content, obj)
+}
+
+// BuildHistory decodes a build_history addition.
+func (a *Addition) BuildHistory() ([]BuildHistoryEntry, error) {
+	var history []BuildHistoryEntry
+	err := a.decode(AdditionTypeBuildHistory, &history)
+	return history, err
+}
+
+// Dependencies decodes the dependencies addition of a chart.
+func (a *Addition) Dependencies() ([]ChartDependency, error) {
+	var dependencies []ChartDependency
+	err := a.decode(AdditionTypeDependencies, &dependencies)
+	return dependencies, err
+}
+
+// Values returns the values.yaml addition of a chart, the YAML text of its default values.
+func (a *Addition) Values() (string, error) {
+	return a.text(AdditionTypeValuesYAML)
+}
+
+// README returns the readme.md addition of a chart, its markdown documentation.
+func (a *Addition) README() (string, error) {
+	return a.text(AdditionTypeReadme)
+}
+
+func (a *Addition) text(kind string) (string, error) {
+	if a.Type != kind {
+		return "", fmt.Errorf("cannot read a %q addition as %q", a.Type, kind)
+	}
+	return string(a.Content), nil
+}
+
+// BuildHistoryEntry is a step of the build of an image, one per layer.
+type BuildHistoryEntry struct {
+	Created    time.Time `json:"created"`
+	CreatedBy  string    `json:"created_by,omitempty"`
+	Author     string    `json:"author,omitempty"`
+	Comment    string    `json:"comment,omitempty"`
+	EmptyLayer bool      `json:"empty_layer,omitempty"`
+}
+
+// ChartDependency is a chart a chart depends on, as listed in its Chart.yaml.
+type ChartDependency struct {
+	Name       string   `json:"name"`
+	Version    string   `json:"version,omitempty"`
+	Repository string   `json:"repository"`
+	Condition  string   `json:"condition,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	Enabled    bool     `json:"enabled,omitempty"`
+	Alias      string   `json:"alias,omitempty"`
+}
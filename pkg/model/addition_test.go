@@ -0,0 +1,67 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import "testing"
+
+func TestAdditionDecoders(t *testing.T) {
+	history := &Addition{
+		Type:    AdditionTypeBuildHistory,
+		Content: []byte(`[{"created":"2020-06-01T10:00:00Z","created_by":"/bin/sh -c #(nop) CMD [\"sh\"]","empty_layer":true}]`),
+	}
+	entries, err := history.BuildHistory()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 1 || !entries[0].EmptyLayer || entries[0].Created.Year() != 2020 {
+		t.Errorf("unexpected build history: %#v", entries)
+	}
+	if _, err := history.Dependencies(); err == nil {
+		t.Errorf("expected decoding a build history as dependencies to fail")
+	}
+
+	dependencies := &Addition{
+		Type:    AdditionTypeDependencies,
+		Content: []byte(`[{"name":"redis","version":"10.5.7","repository":"https://charts.bitnami.com/bitnami"}]`),
+	}
+	deps, err := dependencies.Dependencies()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deps) != 1 || deps[0].Name != "redis" || deps[0].Version != "10.5.7" {
+		t.Errorf("unexpected dependencies: %#v", deps)
+	}
+}
+
+func TestAdditionText(t *testing.T) {
+	values := &Addition{Type: AdditionTypeValuesYAML, ContentType: "text/plain; charset=utf-8", Content: []byte("replicaCount: 1\nimage:\n  repository: nginx\n")}
+	text, err := values.Values()
+	if err != nil || text != "replicaCount: 1\nimage:\n  repository: nginx\n" {
+		t.Errorf("unexpected values %q: %v", text, err)
+	}
+	if _, err := values.README(); err == nil {
+		t.Errorf("expected reading values.yaml as a README to fail")
+	}
+
+	readme := &Addition{Type: AdditionTypeReadme, ContentType: "text/markdown; charset=utf-8", Content: []byte("# nginx\n")}
+	text, err = readme.README()
+	if err != nil || text != "# nginx\n" {
+		t.Errorf("unexpected README %q: %v", text, err)
+	}
+	if _, err := readme.Values(); err == nil {
+		t.Errorf("expected reading a README as values.yaml to fail")
+	}
+}
@@ -26,6 +26,18 @@ type Artifact struct {
 	Tags          []*tag.Tag               `json:"tags"`           // the list of tags that attached to the artifact
 	AdditionLinks map[string]*AdditionLink `json:"addition_links"` // the resource link for build history(image), values.yaml(chart), dependency(chart), etc
 	Labels        []*cmodels.Label         `json:"labels"`
+	Accessories   []*Accessory             `json:"accessories,omitempty"` // the signatures, SBOMs, etc. linked to the artifact
+}
+
+// Signed tells whether any of the accessories of the artifact is a signature. The
+// accessories are only listed when asked for with ArtifactsListOptions.WithAccessory.
+func (a *Artifact) Signed() bool {
+	for _, accessory := range a.Accessories {
+		if accessory != nil && accessory.IsSignature() {
+			return true
+		}
+	}
+	return false
 }
 
 // AdditionLink is a link via that the addition can be fetched
@@ -0,0 +1,125 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// CVEAllowlist lists the CVEs ignored when deciding whether vulnerable images may
+// be pulled, system wide or for a single project.
+type CVEAllowlist struct {
+	ID        int64 `json:"id,omitempty"`
+	ProjectID int64 `json:"project_id,omitempty"`
+	// ExpiresAt The Unix time after which the whole allowlist is ignored, nil if it never expires
+	ExpiresAt    *int64             `json:"expires_at,omitempty"`
+	Items        []CVEAllowlistItem `json:"items"`
+	CreationTime time.Time          `json:"creation_time,omitempty"`
+	UpdateTime   time.Time          `json:"update_time,omitempty"`
+}
+
+// CVEAllowlistItem is a single allowed CVE.
+type CVEAllowlistItem struct {
+	CVEID string `json:"cve_id"`
+}
+
+// Expiry returns the time the allowlist expires, false if it never does.
+func (l *CVEAllowlist) Expiry() (time.Time, bool) {
+	if l.ExpiresAt == nil {
+		return time.Time{}, false
+	}
+	return time.Unix(*l.ExpiresAt, 0), true
+}
+
+// SetExpiry makes the allowlist expire at t, or never if t is zero.
+func (l *CVEAllowlist) SetExpiry(t time.Time) {
+	if t.IsZero() {
+		l.ExpiresAt = nil
+		return
+	}
+	expiresAt := t.Unix()
+	l.ExpiresAt = &expiresAt
+}
+
+// Expired tells whether the allowlist is ignored at now.
+func (l *CVEAllowlist) Expired(now time.Time) bool {
+	return l.ExpiresAt != nil && now.Unix() >= *l.ExpiresAt
+}
+
+// Contains tells whether cve, e.g. "CVE-2021-44228", is allowed.
+func (l *CVEAllowlist) Contains(cve string) bool {
+	for _, item := range l.Items {
+		if item.CVEID == cve {
+			return true
+		}
+	}
+	return false
+}
+
+// Add allows the cves not allowed yet and tells whether the allowlist changed.
+func (l *CVEAllowlist) Add(cves ...string) bool {
+	changed := false
+	for _, cve := range cves {
+		if !l.Contains(cve) {
+			l.Items = append(l.Items, CVEAllowlistItem{CVEID: cve})
+			changed = true
+		}
+	}
+	return changed
+}
+
+// AddUntil allows cves like Add and makes the allowlist expire no later than
+// expiry, so that the exception ends at the latest when expected. A zero expiry
+// is ignored rather than making the allowlist permanent, AddUntil then behaves
+// like Add.
+func (l *CVEAllowlist) AddUntil(expiry time.Time, cves ...string) bool {
+	changed := l.Add(cves...)
+	if expiry.IsZero() {
+		return changed
+	}
+	if current, ok := l.Expiry(); !ok || expiry.Before(current) {
+		l.SetExpiry(expiry)
+		changed = true
+	}
+	return changed
+}
+
+// Remove stops allowing cves and tells whether the allowlist changed.
+func (l *CVEAllowlist) Remove(cves ...string) bool {
+	items := l.Items[:0]
+	for _, item := range l.Items {
+		if !slices.Contains(cves, item.CVEID) {
+			items = append(items, item)
+		}
+	}
+	changed := len(items) != len(l.Items)
+	l.Items = items
+	return changed
+}
+
+// Merge allows the CVEs of other too, expiring at the earliest of both expiries,
+// and tells whether the allowlist changed.
+func (l *CVEAllowlist) Merge(other *CVEAllowlist) bool {
+	cves := make([]string, 0, len(other.Items))
+	for _, item := range other.Items {
+		cves = append(cves, item.CVEID)
+	}
+	if expiry, ok := other.Expiry(); ok {
+		return l.AddUntil(expiry, cves...)
+	}
+	return l.Add(cves...)
+}
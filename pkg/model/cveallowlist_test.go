@@ -0,0 +1,76 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCVEAllowlistAddRemove(t *testing.T) {
+	l := &CVEAllowlist{}
+	if !l.Add("CVE-2021-44228", "CVE-2022-22965") || l.Add("CVE-2021-44228") {
+		t.Errorf("expected only the first Add to change the allowlist")
+	}
+	if !l.Remove("CVE-2021-44228") || l.Remove("CVE-2021-44228") {
+		t.Errorf("expected only the first Remove to change the allowlist")
+	}
+	if want := []CVEAllowlistItem{{CVEID: "CVE-2022-22965"}}; !reflect.DeepEqual(l.Items, want) {
+		t.Errorf("unexpected items %v", l.Items)
+	}
+}
+
+func TestCVEAllowlistExpiry(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := &CVEAllowlist{}
+	if l.Expired(now) {
+		t.Errorf("expected an allowlist without expiry not to expire")
+	}
+	if l.AddUntil(time.Time{}) || l.ExpiresAt != nil {
+		t.Errorf("expected a zero expiry to leave the allowlist unchanged")
+	}
+	if !l.AddUntil(now.Add(48*time.Hour), "CVE-2021-44228") {
+		t.Errorf("expected AddUntil to change the allowlist")
+	}
+	if l.AddUntil(now.Add(72*time.Hour), "CVE-2021-44228") {
+		t.Errorf("expected a later expiry to keep the allowlist unchanged")
+	}
+	l.Merge(&CVEAllowlist{ExpiresAt: ptr(now.Add(24 * time.Hour).Unix()), Items: []CVEAllowlistItem{{CVEID: "CVE-2022-22965"}}})
+	if expiry, ok := l.Expiry(); !ok || !expiry.Equal(now.Add(24*time.Hour)) {
+		t.Errorf("expected the earliest expiry, got %v", expiry)
+	}
+	if len(l.Items) != 2 {
+		t.Errorf("expected the merged CVEs, got %v", l.Items)
+	}
+	if l.Expired(now) || !l.Expired(now.Add(24*time.Hour)) {
+		t.Errorf("unexpected expiry at %v", l.ExpiresAt)
+	}
+	if l.AddUntil(time.Time{}, "CVE-2021-44228") {
+		t.Errorf("expected a zero expiry to keep the allowlist unchanged")
+	}
+	if expiry, ok := l.Expiry(); !ok || !expiry.Equal(now.Add(24*time.Hour)) {
+		t.Errorf("expected a zero expiry to keep the current expiry, got %v", l.ExpiresAt)
+	}
+	l.SetExpiry(time.Time{})
+	if l.ExpiresAt != nil {
+		t.Errorf("expected a zero time to remove the expiry")
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// GCParameters tunes a garbage collection run.
+type GCParameters struct {
+	// DeleteUntagged Whether the untagged artifacts are deleted too
+	DeleteUntagged bool `json:"delete_untagged"`
+	// DryRun Whether the run only reports what it would delete
+	DryRun bool `json:"dry_run"`
+	// Workers The number of workers deleting blobs, 1 to 5
+	Workers int `json:"workers,omitempty"`
+}
+
+// Map returns the parameters in the form Harbor expects in a schedule.
+func (p *GCParameters) Map() map[string]interface{} {
+	if p == nil {
+		return nil
+	}
+	m := map[string]interface{}{
+		"delete_untagged": p.DeleteUntagged,
+		"dry_run":         p.DryRun,
+	}
+	if p.Workers > 0 {
+		m["workers"] = p.Workers
+	}
+	return m
+}
+
+// GCHistory is a garbage collection run.
+type GCHistory struct {
+	ID      int64  `json:"id"`
+	JobName string `json:"job_name"`
+	// JobKind "MANUAL" or "SCHEDULE"
+	JobKind string `json:"job_kind"`
+	// JobParameters The GCParameters of the run, encoded as JSON
+	JobParameters string       `json:"job_parameters"`
+	Schedule      *ScheduleObj `json:"schedule"`
+	// JobStatus e.g. "Running", "Success", "Error" or "Stopped"
+	JobStatus    string    `json:"job_status"`
+	Deleted      bool      `json:"deleted"`
+	CreationTime time.Time `json:"creation_time"`
+	UpdateTime   time.Time `json:"update_time"`
+}
+
+// Parameters decodes the parameters of the run.
+func (h *GCHistory) Parameters() (*GCParameters, error) {
+	params := &GCParameters{}
+	if len(h.JobParameters) == 0 {
+		return params, nil
+	}
+	return params, json.Unmarshal([]byte(h.JobParameters), params)
+}
+
+// GCLogSummary is what a garbage collection run reports deleting, or would delete
+// for a dry run.
+type GCLogSummary struct {
+	Blobs     int64
+	Manifests int64
+	// FreedMB The space freed, or that would be freed, in MB
+	FreedMB int64
+}
+
+var (
+	gcDeletedPattern = regexp.MustCompile(`(\d+) blobs and (\d+) manifests (?:eligible for deletion|are actually deleted)`)
+	gcFreedPattern   = regexp.MustCompile(`The GC (?:could free up|job actual frees up) (\d+) MB space`)
+)
+
+// ParseGCLog extracts the deleted blobs and manifests and the freed space from the
+// log of a garbage collection run. It returns false if the log carries no summary,
+// e.g. because the run has not finished.
+func ParseGCLog(log string) (*GCLogSummary, bool) {
+	deleted := gcDeletedPattern.FindAllStringSubmatch(log, -1)
+	freed := gcFreedPattern.FindAllStringSubmatch(log, -1)
+	if len(deleted) == 0 || len(freed) == 0 {
+		return nil, false
+	}
+	// A summary line is printed once per run, keep the last one.
+	last := deleted[len(deleted)-1]
+	summary := &GCLogSummary{}
+	summary.Blobs, _ = strconv.ParseInt(last[1], 10, 64)
+	summary.Manifests, _ = strconv.ParseInt(last[2], 10, 64)
+	summary.FreedMB, _ = strconv.ParseInt(freed[len(freed)-1][1], 10, 64)
+	return summary, true
+}
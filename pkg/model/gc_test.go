@@ -0,0 +1,44 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGCLog(t *testing.T) {
+	dryRun := `2024-04-08T02:00:00Z [INFO] [/jobservice/job/impl/gc/garbage_collection.go:261]: blob eligible for deletion: sha256:1
+2024-04-08T02:00:00Z [INFO] [/jobservice/job/impl/gc/garbage_collection.go:273]: 12 blobs and 3 manifests eligible for deletion
+2024-04-08T02:00:00Z [INFO] [/jobservice/job/impl/gc/garbage_collection.go:274]: The GC could free up 345 MB space, the size is a rough estimation.
+`
+	summary, ok := ParseGCLog(dryRun)
+	if !ok || !reflect.DeepEqual(summary, &GCLogSummary{Blobs: 12, Manifests: 3, FreedMB: 345}) {
+		t.Errorf("unexpected dry run summary: %#v", summary)
+	}
+
+	run := `2024-04-08T02:00:00Z [INFO] [/jobservice/job/impl/gc/garbage_collection.go:482]: 7 blobs and 2 manifests are actually deleted
+2024-04-08T02:00:00Z [INFO] [/jobservice/job/impl/gc/garbage_collection.go:483]: The GC job actual frees up 120 MB space.
+`
+	summary, ok = ParseGCLog(run)
+	if !ok || !reflect.DeepEqual(summary, &GCLogSummary{Blobs: 7, Manifests: 2, FreedMB: 120}) {
+		t.Errorf("unexpected run summary: %#v", summary)
+	}
+
+	if _, ok := ParseGCLog("2024-04-08T02:00:00Z [INFO] start to run gc in job."); ok {
+		t.Errorf("expected no summary for an unfinished run")
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+// Fixed values of immutable tag rules, the only ones Harbor supports.
+const (
+	ImmutableActionImmutable = "immutable"
+	ImmutableTemplate        = "immutable_template"
+)
+
+// ImmutableRule makes the tags selected by its tag and repository selectors
+// immutable: they can be neither overwritten nor deleted.
+type ImmutableRule struct {
+	ID        int64  `json:"id,omitempty"`
+	ProjectID int64  `json:"project_id,omitempty"`
+	Disabled  bool   `json:"disabled"`
+	Priority  int    `json:"priority,omitempty"`
+	Action    string `json:"action"`
+	Template  string `json:"template"`
+	// TagSelectors select the tags, all of them must match.
+	TagSelectors []*ImmutableSelector `json:"tag_selectors"`
+	// ScopeSelectors select the repositories under the RetentionScopeRepository key.
+	ScopeSelectors map[string][]*ImmutableSelector `json:"scope_selectors"`
+}
+
+// NewImmutableRule returns a rule making the tags selected by tags immutable in the
+// repositories selected by repositories, e.g. the "v*" tags of the "release/**"
+// repositories with
+//
+//	NewImmutableRule(ImmutableRepositories("release/**", false), ImmutableTags("v*", false))
+func NewImmutableRule(repositories, tags *ImmutableSelector) *ImmutableRule {
+	return &ImmutableRule{
+		Action:       ImmutableActionImmutable,
+		Template:     ImmutableTemplate,
+		TagSelectors: []*ImmutableSelector{tags},
+		ScopeSelectors: map[string][]*ImmutableSelector{
+			RetentionScopeRepository: {repositories},
+		},
+	}
+}
+
+// SameSelectors tells whether the rule selects the same repositories and tags as
+// other, regardless of their IDs and of whether they are disabled.
+func (r *ImmutableRule) SameSelectors(other *ImmutableRule) bool {
+	return sameImmutableSelectors(r.TagSelectors, other.TagSelectors) &&
+		sameImmutableSelectors(r.ScopeSelectors[RetentionScopeRepository], other.ScopeSelectors[RetentionScopeRepository])
+}
+
+func sameImmutableSelectors(a, b []*ImmutableSelector) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] == nil || b[i] == nil {
+			if a[i] != b[i] {
+				return false
+			}
+			continue
+		}
+		if *a[i] != *b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ImmutableSelector selects tags or repositories by doublestar pattern, e.g. "v*"
+// or "release/**".
+type ImmutableSelector struct {
+	Kind string `json:"kind"`
+	// Decoration is one of the RetentionDecoration constants.
+	Decoration string `json:"decoration"`
+	Pattern    string `json:"pattern"`
+}
+
+// ImmutableTags selects the tags matching pattern, or the others if exclude.
+func ImmutableTags(pattern string, exclude bool) *ImmutableSelector {
+	decoration := RetentionDecorationMatches
+	if exclude {
+		decoration = RetentionDecorationExcludes
+	}
+	return &ImmutableSelector{Kind: RetentionSelectorKindDoublestar, Decoration: decoration, Pattern: pattern}
+}
+
+// ImmutableRepositories selects the repositories whose name, without the project,
+// matches pattern, or the others if exclude.
+func ImmutableRepositories(pattern string, exclude bool) *ImmutableSelector {
+	decoration := RetentionDecorationRepoMatches
+	if exclude {
+		decoration = RetentionDecorationRepoExcludes
+	}
+	return &ImmutableSelector{Kind: RetentionSelectorKindDoublestar, Decoration: decoration, Pattern: pattern}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import "testing"
+
+func TestImmutableRuleSameSelectors(t *testing.T) {
+	rule := NewImmutableRule(ImmutableRepositories("release/**", false), ImmutableTags("v*", false))
+	existing := NewImmutableRule(ImmutableRepositories("release/**", false), ImmutableTags("v*", false))
+	existing.ID = 3
+	existing.Disabled = true
+	if !rule.SameSelectors(existing) {
+		t.Errorf("expected the rules to have the same selectors")
+	}
+	for _, other := range []*ImmutableRule{
+		NewImmutableRule(ImmutableRepositories("release/**", true), ImmutableTags("v*", false)),
+		NewImmutableRule(ImmutableRepositories("release/**", false), ImmutableTags("v1.*", false)),
+		{TagSelectors: rule.TagSelectors},
+	} {
+		if rule.SameSelectors(other) {
+			t.Errorf("expected different selectors: %+v", other)
+		}
+	}
+
+	// A null selector from the server must not panic.
+	null := &ImmutableRule{TagSelectors: []*ImmutableSelector{nil}, ScopeSelectors: rule.ScopeSelectors}
+	if rule.SameSelectors(null) || null.SameSelectors(rule) {
+		t.Errorf("expected a null selector to differ from a set one")
+	}
+	if !null.SameSelectors(&ImmutableRule{TagSelectors: []*ImmutableSelector{nil}, ScopeSelectors: rule.ScopeSelectors}) {
+		t.Errorf("expected null selectors to be the same")
+	}
+}
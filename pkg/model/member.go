@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+// ProjectRole is the role a member holds in a project.
+type ProjectRole int64
+
+// Roles of project members, as numbered by Harbor.
+const (
+	ProjectAdmin ProjectRole = 1
+	Developer    ProjectRole = 2
+	Guest        ProjectRole = 3
+	Maintainer   ProjectRole = 4
+	LimitedGuest ProjectRole = 5
+)
+
+// String returns the name Harbor gives to the role.
+func (r ProjectRole) String() string {
+	switch r {
+	case ProjectAdmin:
+		return "projectAdmin"
+	case Developer:
+		return "developer"
+	case Guest:
+		return "guest"
+	case Maintainer:
+		return "maintainer"
+	case LimitedGuest:
+		return "limitedGuest"
+	}
+	return "unknown"
+}
+
+// Entity types of project members.
+const (
+	MemberEntityUser  = "u"
+	MemberEntityGroup = "g"
+)
+
+// ProjectMemberEntity is a member of a project as listed by Harbor.
+type ProjectMemberEntity struct {
+	ID         int64       `json:"id"`
+	ProjectID  int64       `json:"project_id"`
+	EntityName string      `json:"entity_name"`
+	RoleName   string      `json:"role_name"`
+	RoleID     ProjectRole `json:"role_id"`
+	EntityID   int64       `json:"entity_id"`
+	// EntityType is MemberEntityUser or MemberEntityGroup.
+	EntityType string `json:"entity_type"`
+}
+
+// ProjectMember is the request adding a user or a group to a project. Exactly one
+// of MemberUser and MemberGroup must be set.
+type ProjectMember struct {
+	RoleID      ProjectRole `json:"role_id"`
+	MemberUser  *UserEntity `json:"member_user,omitempty"`
+	MemberGroup *UserGroup  `json:"member_group,omitempty"`
+}
+
+// UserEntity references a user by ID or by name.
+type UserEntity struct {
+	UserID   int64  `json:"user_id,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// RoleRequest changes the role of a project member.
+type RoleRequest struct {
+	RoleID ProjectRole `json:"role_id"`
+}
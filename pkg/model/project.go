@@ -0,0 +1,181 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import "strconv"
+
+// ProjectReq holds the fields accepted when creating or updating a project.
+type ProjectReq struct {
+	// ProjectName The name of the project.
+	ProjectName string `json:"project_name,omitempty"`
+	// Public deprecated, reserved for project creation in replication
+	Public *bool `json:"public,omitempty"`
+	// Metadata The metadata of the project.
+	Metadata ProjectMetadata `json:"metadata,omitempty"`
+	// StorageLimit The storage quota of the project in bytes, -1 for unlimited.
+	StorageLimit *int64 `json:"storage_limit,omitempty"`
+	// RegistryID The ID of referenced registry when creating the proxy cache project
+	RegistryID *int64 `json:"registry_id,omitempty"`
+	// CVEAllowlist The CVE allowlist of the project, only applied when the project
+	// does not reuse the system allowlist.
+	CVEAllowlist *CVEAllowlist `json:"cve_allowlist,omitempty"`
+}
+
+// ProjectSummary holds the statistics Harbor reports for a project.
+type ProjectSummary struct {
+	// RepoCount The number of the repositories under this project.
+	RepoCount int64 `json:"repo_count"`
+	// ChartCount The total number of charts under this project.
+	ChartCount int64 `json:"chart_count"`
+	// The member counts of the project, one per role.
+	ProjectAdminCount int64 `json:"project_admin_count"`
+	MaintainerCount   int64 `json:"maintainer_count"`
+	DeveloperCount    int64 `json:"developer_count"`
+	GuestCount        int64 `json:"guest_count"`
+	LimitedGuestCount int64 `json:"limited_guest_count"`
+	// Quota The storage quota of the project, nil if quota is not enabled.
+	Quota *ProjectSummaryQuota `json:"quota,omitempty"`
+}
+
+// ProjectSummaryQuota holds the hard limits and the usage of a project quota,
+// keyed by resource name, e.g. "storage".
+type ProjectSummaryQuota struct {
+	Hard map[string]int64 `json:"hard"`
+	Used map[string]int64 `json:"used"`
+}
+
+// ProjectDeletable tells whether a project can be deleted and, if not, why.
+type ProjectDeletable struct {
+	Deletable bool   `json:"deletable"`
+	Message   string `json:"message"`
+}
+
+// Keys of the well-known project metadata.
+const (
+	ProMetaPublic               = "public"
+	ProMetaEnableContentTrust   = "enable_content_trust"
+	ProMetaPreventVul           = "prevent_vul"
+	ProMetaSeverity             = "severity"
+	ProMetaAutoScan             = "auto_scan"
+	ProMetaReuseSysCVEAllowlist = "reuse_sys_cve_allowlist"
+	ProMetaRetentionID          = "retention_id"
+)
+
+// Severities accepted by the "severity" project metadata, the lowest severity
+// that prevents vulnerable images from being pulled.
+const (
+	SeverityNone     = "none"
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+// ProjectMetadata is the metadata of a project. Harbor stores every value as a
+// string, the accessors below convert the well-known keys to their types. The
+// setters allocate the map if needed, so that the zero value is ready to use:
+//
+//	req := model.ProjectReq{ProjectName: "library"}
+//	req.Metadata.SetAutoScan(true)
+type ProjectMetadata map[string]string
+
+func (m ProjectMetadata) bool(key string) bool {
+	v, _ := strconv.ParseBool(m[key])
+	return v
+}
+
+// set sets key to value, allocating the map if it is nil.
+func (m *ProjectMetadata) set(key, value string) {
+	if *m == nil {
+		*m = ProjectMetadata{}
+	}
+	(*m)[key] = value
+}
+
+func (m *ProjectMetadata) setBool(key string, value bool) {
+	m.set(key, strconv.FormatBool(value))
+}
+
+// Public tells whether the project is public.
+func (m ProjectMetadata) Public() bool {
+	return m.bool(ProMetaPublic)
+}
+
+// SetPublic makes the project public or private.
+func (m *ProjectMetadata) SetPublic(v bool) {
+	m.setBool(ProMetaPublic, v)
+}
+
+// EnableContentTrust tells whether only signed images can be pulled.
+func (m ProjectMetadata) EnableContentTrust() bool {
+	return m.bool(ProMetaEnableContentTrust)
+}
+
+// SetEnableContentTrust allows only signed images to be pulled, or not.
+func (m *ProjectMetadata) SetEnableContentTrust(v bool) {
+	m.setBool(ProMetaEnableContentTrust, v)
+}
+
+// PreventVul tells whether vulnerable images are prevented from being pulled.
+func (m ProjectMetadata) PreventVul() bool {
+	return m.bool(ProMetaPreventVul)
+}
+
+// SetPreventVul prevents vulnerable images from being pulled, or not.
+func (m *ProjectMetadata) SetPreventVul(v bool) {
+	m.setBool(ProMetaPreventVul, v)
+}
+
+// AutoScan tells whether images are scanned automatically when pushed.
+func (m ProjectMetadata) AutoScan() bool {
+	return m.bool(ProMetaAutoScan)
+}
+
+// SetAutoScan scans images automatically when pushed, or not.
+func (m *ProjectMetadata) SetAutoScan(v bool) {
+	m.setBool(ProMetaAutoScan, v)
+}
+
+// ReuseSysCVEAllowlist tells whether the system CVE allowlist applies to the project.
+func (m ProjectMetadata) ReuseSysCVEAllowlist() bool {
+	return m.bool(ProMetaReuseSysCVEAllowlist)
+}
+
+// SetReuseSysCVEAllowlist applies the system CVE allowlist to the project, or not.
+func (m *ProjectMetadata) SetReuseSysCVEAllowlist(v bool) {
+	m.setBool(ProMetaReuseSysCVEAllowlist, v)
+}
+
+// Severity returns the lowest severity preventing images from being pulled, one of the Severity constants.
+func (m ProjectMetadata) Severity() string {
+	return m[ProMetaSeverity]
+}
+
+// SetSeverity sets the lowest severity preventing images from being pulled.
+func (m *ProjectMetadata) SetSeverity(severity string) {
+	m.set(ProMetaSeverity, severity)
+}
+
+// RetentionID returns the ID of the tag retention policy of the project, false if there is none.
+func (m ProjectMetadata) RetentionID() (int64, bool) {
+	id, err := strconv.ParseInt(m[ProMetaRetentionID], 10, 64)
+	return id, err == nil
+}
+
+// SetRetentionID sets the ID of the tag retention policy of the project.
+func (m *ProjectMetadata) SetRetentionID(id int64) {
+	m.set(ProMetaRetentionID, strconv.FormatInt(id, 10))
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProjectMetadataSettersOnNilMap(t *testing.T) {
+	req := ProjectReq{ProjectName: "library"}
+	req.Metadata.SetAutoScan(true)
+	req.Metadata.SetPublic(false)
+	req.Metadata.SetSeverity(SeverityHigh)
+	req.Metadata.SetRetentionID(7)
+
+	want := ProjectMetadata{
+		ProMetaAutoScan:    "true",
+		ProMetaPublic:      "false",
+		ProMetaSeverity:    SeverityHigh,
+		ProMetaRetentionID: "7",
+	}
+	if !reflect.DeepEqual(req.Metadata, want) {
+		t.Errorf("unexpected metadata %v", req.Metadata)
+	}
+}
+
+func TestProjectMetadataAccessors(t *testing.T) {
+	var empty ProjectMetadata
+	if empty.Public() || empty.AutoScan() || empty.Severity() != "" {
+		t.Errorf("expected the zero value to read as unset")
+	}
+	if _, ok := empty.RetentionID(); ok {
+		t.Errorf("expected no retention ID")
+	}
+
+	m := ProjectMetadata{}
+	m.SetPublic(true)
+	m.SetEnableContentTrust(true)
+	m.SetPreventVul(true)
+	m.SetAutoScan(true)
+	m.SetReuseSysCVEAllowlist(true)
+	m.SetSeverity(SeverityCritical)
+	m.SetRetentionID(42)
+	if !m.Public() || !m.EnableContentTrust() || !m.PreventVul() || !m.AutoScan() || !m.ReuseSysCVEAllowlist() {
+		t.Errorf("expected every flag to be set: %v", m)
+	}
+	if m.Severity() != SeverityCritical {
+		t.Errorf("unexpected severity %q", m.Severity())
+	}
+	if id, ok := m.RetentionID(); !ok || id != 42 {
+		t.Errorf("unexpected retention ID %d, %v", id, ok)
+	}
+
+	m.SetPublic(false)
+	if m.Public() || m[ProMetaPublic] != "false" {
+		t.Errorf("expected the project to be private: %v", m)
+	}
+	invalid := ProjectMetadata{ProMetaPublic: "yes", ProMetaRetentionID: "none"}
+	if invalid.Public() {
+		t.Errorf("expected an invalid boolean to read as false")
+	}
+	if _, ok := invalid.RetentionID(); ok {
+		t.Errorf("expected an invalid retention ID to be reported as missing")
+	}
+}
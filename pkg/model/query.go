@@ -28,6 +28,9 @@ type Query struct {
 	//integer or time(in format "2020-04-09 02:36:00"). All of these query patterns should be put in the query string "q=xxx"
 	//and splitted by ",". e.g. q=k1=v1,k2=~v2,k3=[min~max]
 	Q string `json:"q,omitempty"`
+	// Sort the resource list in ascending or descending order. e.g. sort by field1 in ascending order
+	// and field2 in descending order with "sort=field1,-field2"
+	Sort string `json:"sort,omitempty"`
 	// An unique ID for the request
 	RequestId string `json:"X-Request-Id,omitempty"`
 }
@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ArtifactReference identifies an artifact as "project/repository:tag",
+// "project/repository@digest" or "project/repository:tag@digest". The repository
+// may itself contain slashes.
+type ArtifactReference struct {
+	Project    string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseArtifactReference parses "project/repository:tag", "project/repository@digest"
+// or "project/repository:tag@digest", in which case the digest identifies the artifact.
+func ParseArtifactReference(s string) (*ArtifactReference, error) {
+	ref := &ArtifactReference{}
+	name := s
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, ref.Digest = name[:i], name[i+1:]
+		if !strings.Contains(ref.Digest, ":") {
+			return nil, fmt.Errorf("invalid digest %q in artifact reference %q", ref.Digest, s)
+		}
+	}
+	// A tag may precede the digest, as in "library/nginx:1.19@sha256:...".
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref.Tag = name[:i], name[i+1:]
+	}
+	i := strings.Index(name, "/")
+	if i <= 0 || i == len(name)-1 {
+		return nil, fmt.Errorf("artifact reference %q must be in the form project/repository:tag or project/repository@digest", s)
+	}
+	ref.Project, ref.Repository = name[:i], name[i+1:]
+	if len(ref.Tag) == 0 && len(ref.Digest) == 0 {
+		return nil, fmt.Errorf("artifact reference %q has neither a tag nor a digest", s)
+	}
+	return ref, nil
+}
+
+// Reference returns the digest of the artifact if known, its tag otherwise.
+func (r ArtifactReference) Reference() string {
+	if len(r.Digest) > 0 {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+// String returns the reference in the form Harbor expects for the "from" parameter
+// of an artifact copy.
+func (r ArtifactReference) String() string {
+	if len(r.Digest) > 0 {
+		return fmt.Sprintf("%s/%s@%s", r.Project, r.Repository, r.Digest)
+	}
+	return fmt.Sprintf("%s/%s:%s", r.Project, r.Repository, r.Tag)
+}
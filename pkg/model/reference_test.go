@@ -0,0 +1,60 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseArtifactReference(t *testing.T) {
+	cases := map[string]*ArtifactReference{
+		"library/nginx:1.19":         {Project: "library", Repository: "nginx", Tag: "1.19"},
+		"library/team/nginx:latest":  {Project: "library", Repository: "team/nginx", Tag: "latest"},
+		"library/nginx@sha256:abc01": {Project: "library", Repository: "nginx", Digest: "sha256:abc01"},
+	}
+	for s, expected := range cases {
+		ref, err := ParseArtifactReference(s)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", s, err)
+			continue
+		}
+		if !reflect.DeepEqual(ref, expected) {
+			t.Errorf("%s: expected %#v, got %#v", s, expected, ref)
+		}
+		if ref.String() != s {
+			t.Errorf("%s: does not round trip, got %s", s, ref.String())
+		}
+	}
+
+	ref, err := ParseArtifactReference("library/team/nginx:1.19@sha256:abc01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &ArtifactReference{Project: "library", Repository: "team/nginx", Tag: "1.19", Digest: "sha256:abc01"}
+	if !reflect.DeepEqual(ref, expected) {
+		t.Errorf("expected %#v, got %#v", expected, ref)
+	}
+	if ref.String() != "library/team/nginx@sha256:abc01" || ref.Reference() != "sha256:abc01" {
+		t.Errorf("expected the digest to identify the artifact, got %s", ref.String())
+	}
+
+	for _, s := range []string{"nginx:latest", "library/nginx", "/nginx:latest", "library/:latest", "library/nginx@abc"} {
+		if _, err := ParseArtifactReference(s); err == nil {
+			t.Errorf("%s: expected an error", s)
+		}
+	}
+}
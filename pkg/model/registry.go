@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import "time"
+
+// Types of the registries Harbor replicates from and to, as named by their adapters.
+const (
+	RegistryTypeHarbor      = "harbor"
+	RegistryTypeDockerHub   = "docker-hub"
+	RegistryTypeDockerV2    = "docker-registry"
+	RegistryTypeAwsECR      = "aws-ecr"
+	RegistryTypeAzureACR    = "azure-acr"
+	RegistryTypeGoogleGCR   = "google-gcr"
+	RegistryTypeGitLab      = "gitlab"
+	RegistryTypeQuay        = "quay"
+	RegistryTypeArtifactory = "jfrog-artifactory"
+	RegistryTypeGithubGHCR  = "github-ghcr"
+)
+
+// Types of registry credentials.
+const (
+	CredentialTypeBasic  = "basic"
+	CredentialTypeOAuth  = "oauth"
+	CredentialTypeSecret = "secret"
+)
+
+// RegistryCredential authenticates Harbor against a registry.
+type RegistryCredential struct {
+	// Type is one of the CredentialType constants.
+	Type         string `json:"type,omitempty"`
+	AccessKey    string `json:"access_key,omitempty"`
+	AccessSecret string `json:"access_secret,omitempty"`
+}
+
+// Registry is a registry endpoint used as the source or destination of replications.
+type Registry struct {
+	ID          int64               `json:"id,omitempty"`
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Type        string              `json:"type"`
+	URL         string              `json:"url"`
+	Credential  *RegistryCredential `json:"credential,omitempty"`
+	Insecure    bool                `json:"insecure"`
+	// Status The health of the registry, "healthy" or "unhealthy"
+	Status       string    `json:"status,omitempty"`
+	CreationTime time.Time `json:"creation_time"`
+	UpdateTime   time.Time `json:"update_time"`
+}
+
+// RegistryUpdate holds the fields of a registry to change, nil fields are left as is.
+type RegistryUpdate struct {
+	Name           *string `json:"name,omitempty"`
+	Description    *string `json:"description,omitempty"`
+	URL            *string `json:"url,omitempty"`
+	CredentialType *string `json:"credential_type,omitempty"`
+	AccessKey      *string `json:"access_key,omitempty"`
+	AccessSecret   *string `json:"access_secret,omitempty"`
+	Insecure       *bool   `json:"insecure,omitempty"`
+}
+
+// RegistryPing checks that Harbor can reach a registry. Either ID references a
+// stored registry, whose settings the other fields override, or Type and URL
+// describe a registry that is not stored yet.
+type RegistryPing struct {
+	ID             *int64  `json:"id,omitempty"`
+	Type           *string `json:"type,omitempty"`
+	URL            *string `json:"url,omitempty"`
+	CredentialType *string `json:"credential_type,omitempty"`
+	AccessKey      *string `json:"access_key,omitempty"`
+	AccessSecret   *string `json:"access_secret,omitempty"`
+	Insecure       *bool   `json:"insecure,omitempty"`
+}
+
+// RegistryInfo describes what can be replicated from or to a registry.
+type RegistryInfo struct {
+	Type                     string         `json:"type"`
+	Description              string         `json:"description"`
+	SupportedResourceFilters []*FilterStyle `json:"supported_resource_filters"`
+	SupportedTriggers        []string       `json:"supported_triggers"`
+	SupportedCopyByChunk     bool           `json:"supported_copy_by_chunk,omitempty"`
+}
+
+// FilterStyle describes a resource filter a registry supports, e.g. by name or tag.
+type FilterStyle struct {
+	Type   string   `json:"type"`
+	Style  string   `json:"style"`
+	Values []string `json:"values,omitempty"`
+}
+
+// AdapterInfo describes how to configure a registry of a given type: its well-known
+// endpoints and how its credentials are entered.
+type AdapterInfo struct {
+	EndpointPattern   *EndpointPattern   `json:"endpoint_pattern"`
+	CredentialPattern *CredentialPattern `json:"credential_pattern"`
+}
+
+// EndpointPattern lists the endpoints of a registry type.
+type EndpointPattern struct {
+	EndpointType string      `json:"endpoint_type"`
+	Endpoints    []*Endpoint `json:"endpoints"`
+}
+
+// Endpoint is a well-known endpoint, e.g. the URL of a cloud region.
+type Endpoint struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// CredentialPattern describes the credential of a registry type.
+type CredentialPattern struct {
+	AccessKeyType    string `json:"access_key_type"`
+	AccessKeyData    string `json:"access_key_data"`
+	AccessSecretType string `json:"access_secret_type"`
+	AccessSecretData string `json:"access_secret_data"`
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import "time"
+
+// Types of replication triggers.
+const (
+	TriggerTypeManual     = "manual"
+	TriggerTypeScheduled  = "scheduled"
+	TriggerTypeEventBased = "event_based"
+)
+
+// Types of replication filters.
+const (
+	FilterTypeName     = "name"
+	FilterTypeTag      = "tag"
+	FilterTypeLabel    = "label"
+	FilterTypeResource = "resource"
+)
+
+// Decorations of replication filters, whether matching resources are replicated or skipped.
+const (
+	FilterDecorationMatches  = "matches"
+	FilterDecorationExcludes = "excludes"
+)
+
+// Resource types a replication filter of type FilterTypeResource selects.
+const (
+	ResourceTypeImage    = "image"
+	ResourceTypeArtifact = "artifact"
+)
+
+// Statuses of replication executions and tasks.
+const (
+	ExecutionStatusInProgress = "InProgress"
+	ExecutionStatusSucceed    = "Succeed"
+	ExecutionStatusFailed     = "Failed"
+	ExecutionStatusStopped    = "Stopped"
+)
+
+// ReplicationPolicy replicates the resources selected by its filters between this
+// Harbor and a registry endpoint. Pull policies set SrcRegistry, push policies
+// DestRegistry, referencing the registry by ID.
+type ReplicationPolicy struct {
+	ID           int64     `json:"id,omitempty"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description,omitempty"`
+	SrcRegistry  *Registry `json:"src_registry,omitempty"`
+	DestRegistry *Registry `json:"dest_registry,omitempty"`
+	// DestNamespace The namespace the resources are replicated to, kept as is if empty
+	DestNamespace string `json:"dest_namespace,omitempty"`
+	// DestNamespaceReplaceCount How many leading path components of the source
+	// repository DestNamespace replaces, -1 to replace all of them
+	DestNamespaceReplaceCount *int8                `json:"dest_namespace_replace_count,omitempty"`
+	Trigger                   *ReplicationTrigger  `json:"trigger"`
+	Filters                   []*ReplicationFilter `json:"filters"`
+	ReplicateDeletion         bool                 `json:"replicate_deletion"`
+	Override                  bool                 `json:"override"`
+	Enabled                   bool                 `json:"enabled"`
+	// Speed The bandwidth limit of the replication in KB/s, -1 for unlimited
+	Speed        *int32    `json:"speed,omitempty"`
+	CopyByChunk  *bool     `json:"copy_by_chunk,omitempty"`
+	CreationTime time.Time `json:"creation_time"`
+	UpdateTime   time.Time `json:"update_time"`
+}
+
+// ReplicationTrigger decides when a replication policy runs.
+type ReplicationTrigger struct {
+	Type            string           `json:"type"`
+	TriggerSettings *TriggerSettings `json:"trigger_settings,omitempty"`
+}
+
+// TriggerSettings holds the cron of scheduled triggers, e.g. "0 0 2 * * *".
+type TriggerSettings struct {
+	Cron string `json:"cron,omitempty"`
+}
+
+// ManualTrigger returns a trigger running the policy only when an execution is started.
+func ManualTrigger() *ReplicationTrigger {
+	return &ReplicationTrigger{Type: TriggerTypeManual}
+}
+
+// ScheduledTrigger returns a trigger running the policy on cron, a six fields cron
+// expression starting with the seconds.
+func ScheduledTrigger(cron string) *ReplicationTrigger {
+	return &ReplicationTrigger{Type: TriggerTypeScheduled, TriggerSettings: &TriggerSettings{Cron: cron}}
+}
+
+// EventBasedTrigger returns a trigger running the policy whenever a resource is
+// pushed or deleted. It is only supported by push policies.
+func EventBasedTrigger() *ReplicationTrigger {
+	return &ReplicationTrigger{Type: TriggerTypeEventBased}
+}
+
+// ReplicationFilter selects the resources a policy replicates. Value is a pattern
+// for name and tag filters, a list of label names for label filters and one of the
+// ResourceType constants for resource filters.
+type ReplicationFilter struct {
+	Type       string      `json:"type"`
+	Value      interface{} `json:"value"`
+	Decoration string      `json:"decoration,omitempty"`
+}
+
+// NameFilter selects the repositories whose name matches pattern, e.g. "library/**".
+func NameFilter(pattern string) *ReplicationFilter {
+	return &ReplicationFilter{Type: FilterTypeName, Value: pattern}
+}
+
+// TagFilter selects the artifacts with a tag matching pattern, or skips them if exclude.
+func TagFilter(pattern string, exclude bool) *ReplicationFilter {
+	return &ReplicationFilter{Type: FilterTypeTag, Value: pattern, Decoration: decoration(exclude)}
+}
+
+// LabelFilter selects the artifacts carrying all of labels, or skips them if exclude.
+func LabelFilter(labels []string, exclude bool) *ReplicationFilter {
+	return &ReplicationFilter{Type: FilterTypeLabel, Value: labels, Decoration: decoration(exclude)}
+}
+
+// ResourceFilter selects the resources of type resourceType.
+func ResourceFilter(resourceType string) *ReplicationFilter {
+	return &ReplicationFilter{Type: FilterTypeResource, Value: resourceType}
+}
+
+func decoration(exclude bool) string {
+	if exclude {
+		return FilterDecorationExcludes
+	}
+	return FilterDecorationMatches
+}
+
+// ReplicationExecution is a run of a replication policy.
+type ReplicationExecution struct {
+	ID         int64  `json:"id"`
+	PolicyID   int64  `json:"policy_id"`
+	Status     string `json:"status"`
+	StatusText string `json:"status_text"`
+	// Trigger What started the execution, "MANUAL", "SCHEDULE" or "EVENT_BASED"
+	Trigger    string    `json:"trigger"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+	Total      int64     `json:"total"`
+	Failed     int64     `json:"failed"`
+	Succeed    int64     `json:"succeed"`
+	InProgress int64     `json:"in_progress"`
+	Stopped    int64     `json:"stopped"`
+}
+
+// Done tells whether the execution has finished, successfully or not.
+func (e *ReplicationExecution) Done() bool {
+	return e.Status != ExecutionStatusInProgress
+}
+
+// ReplicationTask replicates a single resource as part of an execution.
+type ReplicationTask struct {
+	ID                  int64     `json:"id"`
+	ExecutionID         int64     `json:"execution_id"`
+	ResourceType        string    `json:"resource_type"`
+	SourceResource      string    `json:"src_resource"`
+	DestinationResource string    `json:"dst_resource"`
+	Operation           string    `json:"operation"`
+	JobID               string    `json:"job_id"`
+	Status              string    `json:"status"`
+	StartTime           time.Time `json:"start_time"`
+	EndTime             time.Time `json:"end_time"`
+}
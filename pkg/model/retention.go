@@ -0,0 +1,349 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Templates of tag retention rules. The parameter of a rule, if any, is stored
+// under the name of its template in RetentionRule.Params.
+const (
+	// RetentionTemplateLatestPushedK retains the K most recently pushed artifacts.
+	RetentionTemplateLatestPushedK = "latestPushedK"
+	// RetentionTemplateLatestPulledN retains the N most recently pulled artifacts.
+	RetentionTemplateLatestPulledN = "latestPulledN"
+	// RetentionTemplateNDaysSinceLastPush retains the artifacts pushed within the last N days.
+	RetentionTemplateNDaysSinceLastPush = "nDaysSinceLastPush"
+	// RetentionTemplateNDaysSinceLastPull retains the artifacts pulled within the last N days.
+	RetentionTemplateNDaysSinceLastPull = "nDaysSinceLastPull"
+	// RetentionTemplateLastXDays retains the artifacts pushed or pulled within the last X days.
+	RetentionTemplateLastXDays = "lastXDays"
+	// RetentionTemplateLatestActiveK retains the K most recently pushed or pulled artifacts.
+	RetentionTemplateLatestActiveK = "latestActiveK"
+	// RetentionTemplateAlways retains every artifact.
+	RetentionTemplateAlways = "always"
+	// RetentionTemplateNothing retains no artifact.
+	RetentionTemplateNothing = "nothing"
+)
+
+// Fixed values of tag retention policies, the only ones Harbor supports.
+const (
+	RetentionAlgorithmOr            = "or"
+	RetentionActionRetain           = "retain"
+	RetentionTriggerKindSchedule    = "Schedule"
+	RetentionScopeLevelProject      = "project"
+	RetentionScopeRepository        = "repository"
+	RetentionSelectorKindDoublestar = "doublestar"
+)
+
+// Decorations of doublestar selectors, whether the matching tags or repositories
+// are selected or all the others.
+const (
+	RetentionDecorationMatches      = "matches"
+	RetentionDecorationExcludes     = "excludes"
+	RetentionDecorationRepoMatches  = "repoMatches"
+	RetentionDecorationRepoExcludes = "repoExcludes"
+)
+
+// RetentionPolicy is the tag retention policy of a project. Every artifact of the
+// project that no enabled rule retains is deleted when the policy runs.
+type RetentionPolicy struct {
+	ID        int64             `json:"id,omitempty"`
+	Algorithm string            `json:"algorithm"`
+	Rules     []*RetentionRule  `json:"rules"`
+	Trigger   *RetentionTrigger `json:"trigger"`
+	Scope     *RetentionScope   `json:"scope"`
+}
+
+// NewRetentionPolicy returns a policy for the project projectID retaining the
+// artifacts retained by any of rules. It only runs when an execution is started,
+// set Trigger with RetentionSchedule to run it periodically.
+func NewRetentionPolicy(projectID int64, rules ...*RetentionRule) *RetentionPolicy {
+	return &RetentionPolicy{
+		Algorithm: RetentionAlgorithmOr,
+		Rules:     rules,
+		Trigger:   RetentionSchedule(""),
+		Scope:     &RetentionScope{Level: RetentionScopeLevelProject, Reference: projectID},
+	}
+}
+
+// RetentionTrigger tells when a retention policy runs.
+type RetentionTrigger struct {
+	Kind     string                    `json:"kind"`
+	Settings *RetentionTriggerSettings `json:"settings"`
+}
+
+// RetentionTriggerSettings holds the cron of the trigger, empty if the policy is
+// only run manually.
+type RetentionTriggerSettings struct {
+	Cron string `json:"cron"`
+}
+
+// RetentionSchedule returns a trigger running the policy on cron, a six fields cron
+// expression starting with the seconds, e.g. "0 0 0 * * *". An empty cron only runs
+// the policy when an execution is started.
+func RetentionSchedule(cron string) *RetentionTrigger {
+	return &RetentionTrigger{
+		Kind:     RetentionTriggerKindSchedule,
+		Settings: &RetentionTriggerSettings{Cron: cron},
+	}
+}
+
+// RetentionScope is the project a retention policy applies to.
+type RetentionScope struct {
+	Level     string `json:"level"`
+	Reference int64  `json:"ref"`
+}
+
+// RetentionRule retains, among the artifacts selected by its tag and repository
+// selectors, the ones kept by its template.
+//
+// "Keep the last 20 tags of every repository, and every release-* tag" reads:
+//
+//	NewRetentionPolicy(projectID,
+//		RetainLatestPushed(20).ForTags(ExcludingTags("release-*", false)),
+//		RetainAlways().ForTags(MatchingTags("release-*", false)),
+//	)
+//
+// The second rule is needed as the tags no rule retains are deleted.
+type RetentionRule struct {
+	ID       int    `json:"id,omitempty"`
+	Priority int    `json:"priority,omitempty"`
+	Disabled bool   `json:"disabled"`
+	Action   string `json:"action"`
+	// Template is one of the RetentionTemplate constants.
+	Template string                 `json:"template"`
+	Params   map[string]interface{} `json:"params"`
+	// TagSelectors select the artifacts by tag, all of them must match.
+	TagSelectors []*RetentionSelector `json:"tag_selectors"`
+	// ScopeSelectors select the repositories under the RetentionScopeRepository key.
+	ScopeSelectors map[string][]*RetentionSelector `json:"scope_selectors"`
+}
+
+// newRetentionRule returns a rule of template applying to every tagged or untagged
+// artifact of every repository.
+func newRetentionRule(template string, params map[string]interface{}) *RetentionRule {
+	return &RetentionRule{
+		Action:       RetentionActionRetain,
+		Template:     template,
+		Params:       params,
+		TagSelectors: []*RetentionSelector{MatchingTags("**", true)},
+		ScopeSelectors: map[string][]*RetentionSelector{
+			RetentionScopeRepository: {MatchingRepositories("**")},
+		},
+	}
+}
+
+// RetainLatestPushed returns a rule retaining the k most recently pushed artifacts of each repository.
+func RetainLatestPushed(k int) *RetentionRule {
+	return newRetentionRule(RetentionTemplateLatestPushedK, map[string]interface{}{RetentionTemplateLatestPushedK: k})
+}
+
+// RetainLatestPulled returns a rule retaining the n most recently pulled artifacts of each repository.
+func RetainLatestPulled(n int) *RetentionRule {
+	return newRetentionRule(RetentionTemplateLatestPulledN, map[string]interface{}{RetentionTemplateLatestPulledN: n})
+}
+
+// RetainLatestActive returns a rule retaining the k most recently pushed or pulled
+// artifacts of each repository.
+func RetainLatestActive(k int) *RetentionRule {
+	return newRetentionRule(RetentionTemplateLatestActiveK, map[string]interface{}{RetentionTemplateLatestActiveK: k})
+}
+
+// RetainPushedWithin returns a rule retaining the artifacts pushed within the last days.
+func RetainPushedWithin(days int) *RetentionRule {
+	return newRetentionRule(RetentionTemplateNDaysSinceLastPush, map[string]interface{}{RetentionTemplateNDaysSinceLastPush: days})
+}
+
+// RetainPulledWithin returns a rule retaining the artifacts pulled within the last days.
+func RetainPulledWithin(days int) *RetentionRule {
+	return newRetentionRule(RetentionTemplateNDaysSinceLastPull, map[string]interface{}{RetentionTemplateNDaysSinceLastPull: days})
+}
+
+// RetainActiveWithin returns a rule retaining the artifacts pushed or pulled within the last days.
+func RetainActiveWithin(days int) *RetentionRule {
+	return newRetentionRule(RetentionTemplateLastXDays, map[string]interface{}{RetentionTemplateLastXDays: days})
+}
+
+// RetainAlways returns a rule retaining every selected artifact.
+func RetainAlways() *RetentionRule {
+	return newRetentionRule(RetentionTemplateAlways, map[string]interface{}{})
+}
+
+// RetainNothing returns a rule retaining no artifact.
+func RetainNothing() *RetentionRule {
+	return newRetentionRule(RetentionTemplateNothing, map[string]interface{}{})
+}
+
+// ForTags restricts the rule to the artifacts selected by selector and returns the rule.
+func (r *RetentionRule) ForTags(selector *RetentionSelector) *RetentionRule {
+	r.TagSelectors = []*RetentionSelector{selector}
+	return r
+}
+
+// ForRepositories restricts the rule to the repositories selected by selector and
+// returns the rule.
+func (r *RetentionRule) ForRepositories(selector *RetentionSelector) *RetentionRule {
+	r.ScopeSelectors = map[string][]*RetentionSelector{RetentionScopeRepository: {selector}}
+	return r
+}
+
+// Param returns the count or number of days the rule was given, false if its
+// template takes none.
+func (r *RetentionRule) Param() (int, bool) {
+	switch v := r.Params[r.Template].(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	}
+	return 0, false
+}
+
+// RetentionSelector selects tags or repositories by doublestar pattern, e.g.
+// "release-*" or "{app,web}/**".
+type RetentionSelector struct {
+	Kind string `json:"kind"`
+	// Decoration is one of the RetentionDecoration constants.
+	Decoration string `json:"decoration"`
+	Pattern    string `json:"pattern"`
+	// Extras is a JSON document, {"untagged":true} for tag selectors that also
+	// select the untagged artifacts.
+	Extras string `json:"extras,omitempty"`
+}
+
+type retentionSelectorExtras struct {
+	Untagged bool `json:"untagged"`
+}
+
+func tagSelector(decoration, pattern string, untagged bool) *RetentionSelector {
+	return &RetentionSelector{
+		Kind:       RetentionSelectorKindDoublestar,
+		Decoration: decoration,
+		Pattern:    pattern,
+		Extras:     fmt.Sprintf(`{"untagged":%t}`, untagged),
+	}
+}
+
+// MatchingTags selects the artifacts with a tag matching pattern, and the untagged
+// artifacts if untagged.
+func MatchingTags(pattern string, untagged bool) *RetentionSelector {
+	return tagSelector(RetentionDecorationMatches, pattern, untagged)
+}
+
+// ExcludingTags selects the artifacts with a tag not matching pattern, and the
+// untagged artifacts if untagged.
+func ExcludingTags(pattern string, untagged bool) *RetentionSelector {
+	return tagSelector(RetentionDecorationExcludes, pattern, untagged)
+}
+
+// MatchingRepositories selects the repositories whose name, without the project, matches pattern.
+func MatchingRepositories(pattern string) *RetentionSelector {
+	return &RetentionSelector{
+		Kind:       RetentionSelectorKindDoublestar,
+		Decoration: RetentionDecorationRepoMatches,
+		Pattern:    pattern,
+	}
+}
+
+// ExcludingRepositories selects the repositories whose name, without the project,
+// does not match pattern.
+func ExcludingRepositories(pattern string) *RetentionSelector {
+	return &RetentionSelector{
+		Kind:       RetentionSelectorKindDoublestar,
+		Decoration: RetentionDecorationRepoExcludes,
+		Pattern:    pattern,
+	}
+}
+
+// Untagged tells whether the tag selector also selects the untagged artifacts.
+// Selectors without extras do, as Harbor keeps them selected for compatibility.
+func (s *RetentionSelector) Untagged() bool {
+	if s.Extras == "" {
+		return true
+	}
+	extras := retentionSelectorExtras{}
+	if err := json.Unmarshal([]byte(s.Extras), &extras); err != nil {
+		return true
+	}
+	return extras.Untagged
+}
+
+// RetentionMetadata describes the rule templates and selectors Harbor offers.
+type RetentionMetadata struct {
+	Templates      []*RetentionRuleMetadata     `json:"templates"`
+	ScopeSelectors []*RetentionSelectorMetadata `json:"scope_selectors"`
+	TagSelectors   []*RetentionSelectorMetadata `json:"tag_selectors"`
+}
+
+// RetentionRuleMetadata describes a rule template.
+type RetentionRuleMetadata struct {
+	RuleTemplate string                        `json:"rule_template"`
+	DisplayText  string                        `json:"display_text"`
+	Action       string                        `json:"action"`
+	Params       []*RetentionRuleParamMetadata `json:"params"`
+}
+
+// RetentionRuleParamMetadata describes the parameter of a rule template.
+type RetentionRuleParamMetadata struct {
+	Type string `json:"type"`
+	// Unit e.g. "COUNT" or "DAYS"
+	Unit     string `json:"unit"`
+	Required bool   `json:"required"`
+}
+
+// RetentionSelectorMetadata describes a kind of selector and its decorations.
+type RetentionSelectorMetadata struct {
+	DisplayText string   `json:"display_text"`
+	Kind        string   `json:"kind"`
+	Decorations []string `json:"decorations"`
+}
+
+// RetentionExecution is a run of a retention policy.
+type RetentionExecution struct {
+	ID        int64     `json:"id"`
+	PolicyID  int64     `json:"policy_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time,omitempty"`
+	// Status is one of the JobStatus constants.
+	Status string `json:"status"`
+	// Trigger e.g. "MANUAL" or "SCHEDULE"
+	Trigger string `json:"trigger"`
+	DryRun  bool   `json:"dry_run"`
+}
+
+// Done tells whether the execution has finished, successfully or not.
+func (e *RetentionExecution) Done() bool {
+	return e.Status != JobStatusPending && e.Status != JobStatusRunning && e.Status != JobStatusScheduled
+}
+
+// RetentionTask applies a retention policy to a single repository as part of an execution.
+type RetentionTask struct {
+	ID          int64     `json:"id"`
+	ExecutionID int64     `json:"execution_id"`
+	Repository  string    `json:"repository"`
+	JobID       string    `json:"job_id"`
+	Status      string    `json:"status"`
+	StatusCode  int       `json:"status_code"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	// Total is the number of artifacts of the repository, Retained how many of them were kept.
+	Total    int `json:"total"`
+	Retained int `json:"retained"`
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRetentionRuleParam(t *testing.T) {
+	rule := RetainLatestPushed(20)
+	data, err := json.Marshal(rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &RetentionRule{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []*RetentionRule{rule, decoded} {
+		if n, ok := r.Param(); !ok || n != 20 {
+			t.Errorf("expected param 20, got %d, %v", n, ok)
+		}
+	}
+	if _, ok := RetainAlways().Param(); ok {
+		t.Errorf("expected no param for the always template")
+	}
+}
+
+func TestRetentionSelectorUntagged(t *testing.T) {
+	tests := []struct {
+		selector *RetentionSelector
+		untagged bool
+	}{
+		{MatchingTags("**", true), true},
+		{ExcludingTags("release-*", false), false},
+		{&RetentionSelector{Kind: RetentionSelectorKindDoublestar, Pattern: "**"}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.selector.Untagged(); got != tt.untagged {
+			t.Errorf("%q %q: expected untagged %v, got %v", tt.selector.Pattern, tt.selector.Extras, tt.untagged, got)
+		}
+	}
+	if extras := ExcludingTags("release-*", false).Extras; extras != `{"untagged":false}` {
+		t.Errorf("unexpected extras %s", extras)
+	}
+}
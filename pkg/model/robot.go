@@ -0,0 +1,135 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import "time"
+
+// Levels of robot accounts. System robots may be granted access to several projects
+// and to system resources, project robots only to their project.
+const (
+	RobotLevelSystem  = "system"
+	RobotLevelProject = "project"
+)
+
+// Kinds of robot permissions. Project permissions are scoped to the project named by
+// their namespace, "*" for every project, system permissions use the namespace "/".
+const (
+	RobotPermissionKindSystem  = "system"
+	RobotPermissionKindProject = "project"
+)
+
+// RobotNeverExpires is the duration of a robot account that never expires.
+const RobotNeverExpires int64 = -1
+
+// Resources and actions commonly granted to robot accounts.
+const (
+	ResourceRepository = "repository"
+	ResourceArtifact   = "artifact"
+	ResourceTag        = "tag"
+	ResourceScan       = "scan"
+	ResourceLabel      = "artifact-label"
+
+	ActionPull   = "pull"
+	ActionPush   = "push"
+	ActionRead   = "read"
+	ActionList   = "list"
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Access grants an action on a resource, e.g. "pull" on "repository".
+type Access struct {
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+	// Effect is "allow" unless set otherwise.
+	Effect string `json:"effect,omitempty"`
+}
+
+// RobotPermission grants accesses on the resources of a namespace.
+type RobotPermission struct {
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Access    []*Access `json:"access"`
+}
+
+// Robot is a robot account.
+type Robot struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Secret      string `json:"secret,omitempty"`
+	Level       string `json:"level"`
+	// Duration The lifetime of the robot in days, RobotNeverExpires if it never expires
+	Duration int64 `json:"duration"`
+	Editable bool  `json:"editable"`
+	Disable  bool  `json:"disable"`
+	// ExpiresAt The expiry of the robot in seconds since the epoch, -1 if it never expires
+	ExpiresAt    int64              `json:"expires_at"`
+	Permissions  []*RobotPermission `json:"permissions"`
+	CreationTime time.Time          `json:"creation_time"`
+	UpdateTime   time.Time          `json:"update_time"`
+}
+
+// Expires tells whether the robot expires and when.
+func (r *Robot) Expires() (time.Time, bool) {
+	if r.ExpiresAt <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(r.ExpiresAt, 0), true
+}
+
+// Expired tells whether the robot has expired at now.
+func (r *Robot) Expired(now time.Time) bool {
+	expiry, ok := r.Expires()
+	return ok && !now.Before(expiry)
+}
+
+// RobotCreate holds the fields accepted when creating a robot account.
+type RobotCreate struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Secret The secret of the robot, generated by Harbor if empty
+	Secret  string `json:"secret,omitempty"`
+	Level   string `json:"level"`
+	Disable bool   `json:"disable"`
+	// Duration The lifetime of the robot in days, RobotNeverExpires if it never expires
+	Duration    int64              `json:"duration"`
+	Permissions []*RobotPermission `json:"permissions"`
+}
+
+// RobotCreated is the robot account Harbor created, the only time its secret is returned.
+type RobotCreated struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	Secret       string    `json:"secret"`
+	CreationTime time.Time `json:"creation_time"`
+	ExpiresAt    int64     `json:"expires_at"`
+}
+
+// RobotSec holds the secret of a robot account.
+type RobotSec struct {
+	Secret string `json:"secret"`
+}
+
+// RobotCredential is what a client needs to authenticate as a robot account.
+type RobotCredential struct {
+	// Name The full name of the robot, including the robot prefix
+	Name   string
+	Secret string
+	// ExpiresAt The expiry of the robot in seconds since the epoch, -1 if it never expires
+	ExpiresAt int64
+}
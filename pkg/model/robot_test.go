@@ -0,0 +1,51 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRobotExpires(t *testing.T) {
+	now := time.Unix(1712534400, 0)
+	for _, c := range []struct {
+		expiresAt int64
+		expires   bool
+		expired   bool
+	}{
+		{expiresAt: -1},
+		{expiresAt: 0},
+		{expiresAt: now.Unix() + 3600, expires: true},
+		{expiresAt: now.Unix(), expires: true, expired: true},
+		{expiresAt: now.Unix() - 3600, expires: true, expired: true},
+	} {
+		robot := &Robot{ExpiresAt: c.expiresAt}
+		expiry, expires := robot.Expires()
+		if expires != c.expires {
+			t.Errorf("expires_at %d: expected Expires %t", c.expiresAt, c.expires)
+		}
+		if expires && !expiry.Equal(time.Unix(c.expiresAt, 0)) {
+			t.Errorf("expires_at %d: unexpected expiry %s", c.expiresAt, expiry)
+		}
+		if !expires && !expiry.IsZero() {
+			t.Errorf("expires_at %d: expected no expiry, got %s", c.expiresAt, expiry)
+		}
+		if expired := robot.Expired(now); expired != c.expired {
+			t.Errorf("expires_at %d: expected Expired %t", c.expiresAt, c.expired)
+		}
+	}
+}
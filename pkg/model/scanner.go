@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import "time"
+
+// Authentication schemes of scanner adapters.
+const (
+	ScannerAuthNone   = ""
+	ScannerAuthBasic  = "Basic"
+	ScannerAuthBearer = "Bearer"
+	ScannerAuthAPIKey = "X-ScannerAdapter-API-Key"
+)
+
+// ScannerRegistration is a scanner adapter registered in Harbor.
+type ScannerRegistration struct {
+	UUID        string `json:"uuid"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	Disabled    bool   `json:"disabled"`
+	IsDefault   bool   `json:"is_default"`
+	// Auth is one of the ScannerAuth constants.
+	Auth             string `json:"auth"`
+	AccessCredential string `json:"access_credential,omitempty"`
+	SkipCertVerify   bool   `json:"skip_certVerify"`
+	UseInternalAddr  bool   `json:"use_internal_addr"`
+	// Adapter, Vendor and Version describe the scanner, as reported by the adapter.
+	Adapter string `json:"adapter,omitempty"`
+	Vendor  string `json:"vendor,omitempty"`
+	Version string `json:"version,omitempty"`
+	// Health The health of the adapter, "healthy" or "unhealthy"
+	Health     string    `json:"health,omitempty"`
+	CreateTime time.Time `json:"create_time"`
+	UpdateTime time.Time `json:"update_time"`
+}
+
+// ScannerRegistrationReq holds the fields accepted when registering or updating a scanner adapter.
+type ScannerRegistrationReq struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+	// Auth is one of the ScannerAuth constants.
+	Auth             string `json:"auth,omitempty"`
+	AccessCredential string `json:"access_credential,omitempty"`
+	SkipCertVerify   bool   `json:"skip_certVerify"`
+	UseInternalAddr  bool   `json:"use_internal_addr"`
+	Disabled         bool   `json:"disabled"`
+}
+
+// ScannerAdapterMetadata describes what a scanner adapter can scan and produce.
+type ScannerAdapterMetadata struct {
+	Scanner      *ScannerInfo         `json:"scanner"`
+	Capabilities []*ScannerCapability `json:"capabilities"`
+	Properties   map[string]string    `json:"properties"`
+}
+
+// ScannerCapability lists the mime types of the artifacts a scanner consumes and
+// of the reports it produces for them.
+type ScannerCapability struct {
+	// Type The kind of scan, e.g. "vulnerability" or "sbom"
+	Type              string   `json:"type,omitempty"`
+	ConsumesMimeTypes []string `json:"consumes_mime_types"`
+	ProducesMimeTypes []string `json:"produces_mime_types"`
+}
+
+// ScanAllMetrics reports the progress of a scan all run.
+type ScanAllMetrics struct {
+	Total     int64 `json:"total"`
+	Completed int64 `json:"completed"`
+	// Metrics The number of artifacts per scan status
+	Metrics map[string]int64 `json:"metrics"`
+	Ongoing bool             `json:"ongoing"`
+	// Trigger What started the run, "Manual", "Schedule" or "Event"
+	Trigger string `json:"trigger"`
+}
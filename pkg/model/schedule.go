@@ -0,0 +1,68 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import "time"
+
+// Types of the schedules of system jobs such as scan all and garbage collection.
+const (
+	ScheduleTypeNone   = "None"
+	ScheduleTypeHourly = "Hourly"
+	ScheduleTypeDaily  = "Daily"
+	ScheduleTypeWeekly = "Weekly"
+	ScheduleTypeCustom = "Custom"
+	ScheduleTypeManual = "Manual"
+)
+
+// Statuses of the jobs run by Harbor, such as garbage collections and the
+// executions and tasks of tag retention policies.
+const (
+	JobStatusPending   = "Pending"
+	JobStatusRunning   = "Running"
+	JobStatusStopped   = "Stopped"
+	JobStatusError     = "Error"
+	JobStatusSuccess   = "Success"
+	JobStatusScheduled = "Scheduled"
+)
+
+// Schedule is the schedule of a system job.
+type Schedule struct {
+	ID           int64                  `json:"id,omitempty"`
+	Status       string                 `json:"status,omitempty"`
+	CreationTime time.Time              `json:"creation_time"`
+	UpdateTime   time.Time              `json:"update_time"`
+	Schedule     *ScheduleObj           `json:"schedule"`
+	Parameters   map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// ScheduleObj tells when a system job runs.
+type ScheduleObj struct {
+	// Type is one of the ScheduleType constants.
+	Type string `json:"type"`
+	// Cron A six fields cron expression starting with the seconds, for Custom schedules
+	Cron              string     `json:"cron,omitempty"`
+	NextScheduledTime *time.Time `json:"next_scheduled_time,omitempty"`
+}
+
+// CronSchedule returns a schedule running the job on cron, e.g. "0 0 2 * * *".
+func CronSchedule(cron string) *ScheduleObj {
+	return &ScheduleObj{Type: ScheduleTypeCustom, Cron: cron}
+}
+
+// NoSchedule returns a schedule never running the job.
+func NoSchedule() *ScheduleObj {
+	return &ScheduleObj{Type: ScheduleTypeNone}
+}
@@ -0,0 +1,27 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import (
+	"github.com/goharbor/harbor/src/pkg/tag/model/tag"
+)
+
+// Tag is a tag attached to an artifact, as returned by the tags API.
+type Tag struct {
+	tag.Tag
+	Immutable bool `json:"immutable"` // whether the tag is protected by an immutable rule
+	Signed    bool `json:"signed"`    // whether the tag is signed
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+// UserCreationReq holds the fields accepted when creating a user.
+type UserCreationReq struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Realname string `json:"realname"`
+	Password string `json:"password"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// UserProfile holds the fields of a user that can be updated.
+type UserProfile struct {
+	Email    string `json:"email,omitempty"`
+	Realname string `json:"realname,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// PasswordReq changes the password of a user. OldPassword is not required when
+// a system admin resets the password of another user.
+type PasswordReq struct {
+	OldPassword string `json:"old_password,omitempty"`
+	NewPassword string `json:"new_password"`
+}
+
+// SysAdminFlag grants or revokes the system admin role of a user.
+type SysAdminFlag struct {
+	SysAdminFlag bool `json:"sysadmin_flag"`
+}
+
+// Permission is an action the current user may take on a resource.
+type Permission struct {
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+}
+
+// UserSearchResult is a user matching a search.
+type UserSearchResult struct {
+	UserID   int64  `json:"user_id"`
+	Username string `json:"username"`
+}
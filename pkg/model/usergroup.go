@@ -0,0 +1,56 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+// UserGroupType is the kind of authentication backing a user group.
+type UserGroupType int64
+
+// Types of user groups, as numbered by Harbor.
+const (
+	UserGroupTypeLDAP UserGroupType = 1
+	UserGroupTypeHTTP UserGroupType = 2
+	UserGroupTypeOIDC UserGroupType = 3
+)
+
+// String returns the name of the group type.
+func (t UserGroupType) String() string {
+	switch t {
+	case UserGroupTypeLDAP:
+		return "ldap"
+	case UserGroupTypeHTTP:
+		return "http"
+	case UserGroupTypeOIDC:
+		return "oidc"
+	}
+	return "unknown"
+}
+
+// UserGroup is a group of users authenticated by LDAP, HTTP or OIDC. When adding a
+// group to a project it is referenced by ID if set, otherwise by name and type, or
+// by LDAP DN for LDAP groups.
+type UserGroup struct {
+	ID          int64         `json:"id,omitempty"`
+	GroupName   string        `json:"group_name,omitempty"`
+	GroupType   UserGroupType `json:"group_type,omitempty"`
+	LDAPGroupDN string        `json:"ldap_group_dn,omitempty"`
+}
+
+// UserGroupSearchResult is a user group matching a search.
+type UserGroupSearchResult struct {
+	ID        int64         `json:"id"`
+	GroupName string        `json:"group_name"`
+	GroupType UserGroupType `json:"group_type"`
+}
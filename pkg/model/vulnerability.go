@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+// Mime types of the vulnerability reports Harbor returns as the "vulnerabilities"
+// addition of an artifact.
+const (
+	MimeTypeNativeReport               = "application/vnd.scanner.adapter.vuln.report.harbor+json; version=1.0"
+	MimeTypeGenericVulnerabilityReport = "application/vnd.security.vulnerability.report; version=1.1"
+)
+
+// VulnerabilitySeverity is the scale scanners use to rate a vulnerability. Unlike the
+// "severity" project metadata its values are capitalized.
+type VulnerabilitySeverity string
+
+const (
+	VulnerabilitySeverityNone       VulnerabilitySeverity = "None"
+	VulnerabilitySeverityUnknown    VulnerabilitySeverity = "Unknown"
+	VulnerabilitySeverityNegligible VulnerabilitySeverity = "Negligible"
+	VulnerabilitySeverityLow        VulnerabilitySeverity = "Low"
+	VulnerabilitySeverityMedium     VulnerabilitySeverity = "Medium"
+	VulnerabilitySeverityHigh       VulnerabilitySeverity = "High"
+	VulnerabilitySeverityCritical   VulnerabilitySeverity = "Critical"
+)
+
+// Code returns a number ordering the severities, so that they can be compared.
+// Unrecognized severities rank above Critical to stay on the safe side.
+func (s VulnerabilitySeverity) Code() int {
+	switch s {
+	case VulnerabilitySeverityNone, VulnerabilitySeverityUnknown:
+		return 0
+	case VulnerabilitySeverityNegligible:
+		return 1
+	case VulnerabilitySeverityLow:
+		return 2
+	case VulnerabilitySeverityMedium:
+		return 3
+	case VulnerabilitySeverityHigh:
+		return 4
+	case VulnerabilitySeverityCritical:
+		return 5
+	default:
+		return 99
+	}
+}
+
+// VulnerabilityReports holds the vulnerability reports of an artifact keyed by mime type.
+type VulnerabilityReports map[string]*VulnerabilityReport
+
+// Report returns the generic report if present, the native one otherwise, nil if
+// the artifact carries neither.
+func (r VulnerabilityReports) Report() *VulnerabilityReport {
+	for _, mimeType := range []string{MimeTypeGenericVulnerabilityReport, MimeTypeNativeReport} {
+		if report, ok := r[mimeType]; ok && report != nil {
+			return report
+		}
+	}
+	return nil
+}
+
+// VulnerabilityReport is the result of scanning an artifact.
+type VulnerabilityReport struct {
+	// GeneratedAt Time of generating this report
+	GeneratedAt string `json:"generated_at"`
+	// Scanner The scanner that generated this report
+	Scanner *ScannerInfo `json:"scanner"`
+	// Severity The highest severity of the vulnerabilities found
+	Severity VulnerabilitySeverity `json:"severity"`
+	// Vulnerabilities The vulnerabilities found
+	Vulnerabilities []*VulnerabilityItem `json:"vulnerabilities"`
+}
+
+// ScannerInfo identifies the scanner that generated a report.
+type ScannerInfo struct {
+	Name    string `json:"name"`
+	Vendor  string `json:"vendor"`
+	Version string `json:"version"`
+}
+
+// VulnerabilityItem is a vulnerability found in a package of an artifact.
+type VulnerabilityItem struct {
+	// ID The unique identifier of the vulnerability, e.g. CVE-2017-8283
+	ID string `json:"id"`
+	// Package The operating system or software dependency package containing the vulnerability
+	Package string `json:"package"`
+	// Version The version of the package containing the vulnerability
+	Version string `json:"version"`
+	// FixVersion The version of the package containing the fix, empty if there is none
+	FixVersion string `json:"fix_version"`
+	// Severity The severity of the vulnerability
+	Severity    VulnerabilitySeverity `json:"severity"`
+	Description string                `json:"description"`
+	// Links The links to the upstream databases describing the vulnerability
+	Links []string `json:"links"`
+	// CVSS The CVSS3 and CVSS2 scores and attack vectors of the vulnerability
+	CVSS CVSS `json:"preferred_cvss"`
+	// CWEIDs The CWE IDs associated with the vulnerability
+	CWEIDs []string `json:"cwe_ids"`
+	// VendorAttributes The scanner specific attributes of the vulnerability
+	VendorAttributes map[string]interface{} `json:"vendor_attributes"`
+}
+
+// CVSS holds the scores and attack vectors of a vulnerability in the CVSS3 and
+// CVSS2 standards, nil scores are not known.
+type CVSS struct {
+	ScoreV3  *float64 `json:"score_v3"`
+	ScoreV2  *float64 `json:"score_v2"`
+	VectorV3 string   `json:"vector_v3"`
+	VectorV2 string   `json:"vector_v2"`
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// vulnerabilitiesPayload is the "vulnerabilities" addition of an artifact scanned by Trivy.
+const vulnerabilitiesPayload = `{
+  "application/vnd.security.vulnerability.report; version=1.1": {
+    "generated_at": "2024-04-08T02:00:03.547Z",
+    "scanner": {"name": "Trivy", "vendor": "Aqua Security", "version": "v0.50.1"},
+    "severity": "High",
+    "vulnerabilities": [
+      {
+        "id": "CVE-2023-44487",
+        "package": "golang.org/x/net",
+        "version": "v0.7.0",
+        "fix_version": "0.17.0",
+        "severity": "High",
+        "description": "The HTTP/2 protocol allows a denial of service (server resource consumption) because request cancellation can reset many streams quickly.",
+        "links": ["https://avd.aquasec.com/nvd/cve-2023-44487"],
+        "artifact_digests": ["sha256:3e4c0ec8b5bbd23a2ad3f2cb3d9ac44a7ce0c5c0fac3b9a61d6a2fdea4bb5e1f"],
+        "preferred_cvss": {
+          "score_v3": 7.5,
+          "score_v2": null,
+          "vector_v3": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:H",
+          "vector_v2": ""
+        },
+        "cwe_ids": ["CWE-400"],
+        "vendor_attributes": {"CVSS": {"nvd": {"V3Score": 7.5, "V3Vector": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:H"}}}
+      },
+      {
+        "id": "CVE-2023-45853",
+        "package": "zlib1g",
+        "version": "1:1.2.13.dfsg-1",
+        "fix_version": "",
+        "severity": "Critical",
+        "description": "MiniZip in zlib through 1.3 has an integer overflow.",
+        "links": ["https://avd.aquasec.com/nvd/cve-2023-45853"],
+        "preferred_cvss": {"score_v3": 9.8, "score_v2": null, "vector_v3": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", "vector_v2": ""},
+        "cwe_ids": ["CWE-190"],
+        "vendor_attributes": null
+      }
+    ]
+  }
+}`
+
+func TestVulnerabilityReportsDecode(t *testing.T) {
+	reports := VulnerabilityReports{}
+	if err := json.Unmarshal([]byte(vulnerabilitiesPayload), &reports); err != nil {
+		t.Fatal(err)
+	}
+	report := reports.Report()
+	if report == nil {
+		t.Fatal("expected a report")
+	}
+	if report.Severity != VulnerabilitySeverityHigh || report.Scanner == nil || report.Scanner.Name != "Trivy" {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if len(report.Vulnerabilities) != 2 {
+		t.Fatalf("expected 2 vulnerabilities, got %d", len(report.Vulnerabilities))
+	}
+	first := report.Vulnerabilities[0]
+	if first.ID != "CVE-2023-44487" || first.FixVersion != "0.17.0" || first.Severity != VulnerabilitySeverityHigh {
+		t.Errorf("unexpected vulnerability %+v", first)
+	}
+	if first.CVSS.ScoreV3 == nil || *first.CVSS.ScoreV3 != 7.5 || first.CVSS.ScoreV2 != nil {
+		t.Errorf("unexpected CVSS %+v", first.CVSS)
+	}
+	if len(first.CWEIDs) != 1 || first.VendorAttributes["CVSS"] == nil {
+		t.Errorf("unexpected CWE IDs or vendor attributes %+v", first)
+	}
+	if second := report.Vulnerabilities[1]; second.FixVersion != "" || second.Severity != VulnerabilitySeverityCritical {
+		t.Errorf("unexpected vulnerability %+v", second)
+	}
+}
+
+func TestVulnerabilityReportsReport(t *testing.T) {
+	native := &VulnerabilityReport{Severity: VulnerabilitySeverityLow}
+	generic := &VulnerabilityReport{Severity: VulnerabilitySeverityMedium}
+
+	if report := (VulnerabilityReports{MimeTypeNativeReport: native, MimeTypeGenericVulnerabilityReport: generic}).Report(); report != generic {
+		t.Errorf("expected the generic report to be preferred, got %+v", report)
+	}
+	if report := (VulnerabilityReports{MimeTypeNativeReport: native, MimeTypeGenericVulnerabilityReport: nil}).Report(); report != native {
+		t.Errorf("expected the native report as fallback, got %+v", report)
+	}
+	if report := (VulnerabilityReports{}).Report(); report != nil {
+		t.Errorf("expected no report for an artifact never scanned, got %+v", report)
+	}
+}
+
+func TestVulnerabilitySeverityCode(t *testing.T) {
+	ordered := []VulnerabilitySeverity{
+		VulnerabilitySeverityNone,
+		VulnerabilitySeverityNegligible,
+		VulnerabilitySeverityLow,
+		VulnerabilitySeverityMedium,
+		VulnerabilitySeverityHigh,
+		VulnerabilitySeverityCritical,
+		"Catastrophic",
+	}
+	for i := 1; i < len(ordered); i++ {
+		if ordered[i-1].Code() >= ordered[i].Code() {
+			t.Errorf("expected %s to rank below %s", ordered[i-1], ordered[i])
+		}
+	}
+	if VulnerabilitySeverityUnknown.Code() != VulnerabilitySeverityNone.Code() {
+		t.Errorf("expected Unknown to rank as None")
+	}
+	// A CI gate failing on High and above.
+	gate := VulnerabilitySeverityHigh.Code()
+	if VulnerabilitySeverityMedium.Code() >= gate || VulnerabilitySeverityCritical.Code() < gate {
+		t.Errorf("unexpected gate outcome")
+	}
+}
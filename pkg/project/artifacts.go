@@ -16,7 +16,11 @@ See the License for the specific language governing permissions and
 package project
 
 import (
+	"context"
 	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/TimeBye/go-harbor/pkg/model"
 	"github.com/TimeBye/go-harbor/pkg/project/options"
 	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
@@ -26,8 +30,47 @@ type ArtifactInterface interface {
 	Get(name string) (result *model.Artifact, err error)
 	Delete(name string) (err error)
 	List(query *options.ArtifactsListOptions) (result *[]model.Artifact, err error)
+	GetContext(ctx context.Context, name string) (result *model.Artifact, err error)
+	DeleteContext(ctx context.Context, name string) (err error)
+	ListContext(ctx context.Context, query *options.ArtifactsListOptions) (result *[]model.Artifact, err error)
+	ListPager(ctx context.Context, query *options.ArtifactsListOptions) *rest2.Pager[model.Artifact]
+	ListAll(query *options.ArtifactsListOptions) (result *[]model.Artifact, err error)
+	ListAllContext(ctx context.Context, query *options.ArtifactsListOptions) (result *[]model.Artifact, err error)
+	ListTags(reference string, query *options.TagsListOptions) (result *[]model.Tag, err error)
+	CreateTag(reference, tag string) (err error)
+	DeleteTag(reference, tag string) (err error)
+	ListTagsContext(ctx context.Context, reference string, query *options.TagsListOptions) (result *[]model.Tag, err error)
+	CreateTagContext(ctx context.Context, reference, tag string) (err error)
+	DeleteTagContext(ctx context.Context, reference, tag string) (err error)
+	CopyFrom(source model.ArtifactReference) (location string, err error)
+	CopyFromContext(ctx context.Context, source model.ArtifactReference) (location string, err error)
+	AddLabel(reference string, labelID int64) (err error)
+	RemoveLabel(reference string, labelID int64) (err error)
+	AddLabelContext(ctx context.Context, reference string, labelID int64) (err error)
+	RemoveLabelContext(ctx context.Context, reference string, labelID int64) (err error)
+	Scan(reference string) (err error)
+	StopScan(reference string) (err error)
+	ScanLog(reference, reportID string) (log string, err error)
+	Vulnerabilities(reference string) (result model.VulnerabilityReports, err error)
+	ScanContext(ctx context.Context, reference string) (err error)
+	StopScanContext(ctx context.Context, reference string) (err error)
+	ScanLogContext(ctx context.Context, reference, reportID string) (log string, err error)
+	VulnerabilitiesContext(ctx context.Context, reference string) (result model.VulnerabilityReports, err error)
+	Addition(reference, kind string) (result *model.Addition, err error)
+	AdditionByLink(kind string, link *model.AdditionLink) (result *model.Addition, err error)
+	AdditionContext(ctx context.Context, reference, kind string) (result *model.Addition, err error)
+	AdditionByLinkContext(ctx context.Context, kind string, link *model.AdditionLink) (result *model.Addition, err error)
+	Accessories(reference string, query *model.Query) (result *[]model.Accessory, err error)
+	AccessoriesPager(ctx context.Context, reference string, query *model.Query) *rest2.Pager[model.Accessory]
+	Referrers(digest, artifactType string) (result *model.ReferrersIndex, err error)
+	AccessoriesContext(ctx context.Context, reference string, query *model.Query) (result *[]model.Accessory, err error)
+	ReferrersContext(ctx context.Context, digest, artifactType string) (result *model.ReferrersIndex, err error)
 }
 
+// headerAcceptVulnerabilities lists the mime types of the vulnerability reports a
+// client accepts, Harbor omits the reports of any other type.
+const headerAcceptVulnerabilities = "X-Accept-Vulnerabilities"
+
 type artifact struct {
 	client     rest2.Interface
 	project    string
@@ -44,11 +87,17 @@ func newArtifacts(rest rest2.Interface, project, repository string) *artifact {
 }
 
 func (r *artifact) Get(name string) (result *model.Artifact, err error) {
+	return r.GetContext(context.Background(), name)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (r *artifact) GetContext(ctx context.Context, name string) (result *model.Artifact, err error) {
 	result = &model.Artifact{}
 	err = r.client.Get().
+		Context(ctx).
 		Project(r.project).
 		Resource("repositories").
-		Name(r.repository).
+		Name(escapeRepository(r.repository)).
 		Suffix(fmt.Sprintf("/artifacts/%s", name)).
 		Do().
 		Into(result)
@@ -56,11 +105,17 @@ func (r *artifact) Get(name string) (result *model.Artifact, err error) {
 }
 
 func (r *artifact) List(query *options.ArtifactsListOptions) (result *[]model.Artifact, err error) {
+	return r.ListContext(context.Background(), query)
+}
+
+// ListContext is like List but binds the request to ctx.
+func (r *artifact) ListContext(ctx context.Context, query *options.ArtifactsListOptions) (result *[]model.Artifact, err error) {
 	result = &[]model.Artifact{}
 	err = r.client.Get().
+		Context(ctx).
 		Project(r.project).
 		Resource("repositories").
-		Name(r.repository).
+		Name(escapeRepository(r.repository)).
 		Suffix("/artifacts").
 		Params(*query).
 		Do().
@@ -68,13 +123,294 @@ func (r *artifact) List(query *options.ArtifactsListOptions) (result *[]model.Ar
 	return
 }
 
+// ListPager returns a Pager walking every artifact of the repository matching query.
+func (r *artifact) ListPager(ctx context.Context, query *options.ArtifactsListOptions) *rest2.Pager[model.Artifact] {
+	return rest2.NewPager[model.Artifact](ctx, func() *rest2.Request {
+		return r.client.Get().
+			Project(r.project).
+			Resource("repositories").
+			Name(escapeRepository(r.repository)).
+			Suffix("/artifacts").
+			Params(*query)
+	})
+}
+
+// ListAll returns every artifact of the repository matching query, walking all pages.
+func (r *artifact) ListAll(query *options.ArtifactsListOptions) (result *[]model.Artifact, err error) {
+	return r.ListAllContext(context.Background(), query)
+}
+
+// ListAllContext is like ListAll but binds the requests to ctx.
+func (r *artifact) ListAllContext(ctx context.Context, query *options.ArtifactsListOptions) (result *[]model.Artifact, err error) {
+	items, err := r.ListPager(ctx, query).All()
+	return &items, err
+}
+
 func (r *artifact) Delete(name string) (err error) {
+	return r.DeleteContext(context.Background(), name)
+}
+
+// DeleteContext is like Delete but binds the request to ctx.
+func (r *artifact) DeleteContext(ctx context.Context, name string) (err error) {
 	err = r.client.Delete().
+		Context(ctx).
 		Project(r.project).
 		Resource("repositories").
-		Name(r.repository).
+		Name(escapeRepository(r.repository)).
 		Suffix(fmt.Sprintf("/artifacts/%s", name)).
 		Do().
 		Error()
 	return
 }
+
+// artifactRequest points req at the artifact reference, a digest or a tag, of the repository.
+func (r *artifact) artifactRequest(req *rest2.Request, reference string) *rest2.Request {
+	return req.Project(r.project).
+		Resource("repositories").
+		Name(escapeRepository(r.repository)).
+		Suffix("artifacts", reference)
+}
+
+// ListTags lists the tags attached to the artifact reference.
+func (r *artifact) ListTags(reference string, query *options.TagsListOptions) (result *[]model.Tag, err error) {
+	return r.ListTagsContext(context.Background(), reference, query)
+}
+
+// ListTagsContext is like ListTags but binds the request to ctx.
+func (r *artifact) ListTagsContext(ctx context.Context, reference string, query *options.TagsListOptions) (result *[]model.Tag, err error) {
+	result = &[]model.Tag{}
+	err = r.artifactRequest(r.client.Get().Context(ctx), reference).
+		Suffix("tags").
+		Params(*query).
+		Do().
+		Into(result)
+	return
+}
+
+// CreateTag attaches tag to the artifact reference, typically a "sha256:" digest,
+// without pushing the artifact again.
+func (r *artifact) CreateTag(reference, tag string) (err error) {
+	return r.CreateTagContext(context.Background(), reference, tag)
+}
+
+// CreateTagContext is like CreateTag but binds the request to ctx.
+func (r *artifact) CreateTagContext(ctx context.Context, reference, tag string) (err error) {
+	err = r.artifactRequest(r.client.Post().Context(ctx), reference).
+		Suffix("tags").
+		Body(map[string]string{"name": tag}).
+		Do().
+		Error()
+	return
+}
+
+// DeleteTag detaches tag from the artifact reference, leaving the artifact in place.
+func (r *artifact) DeleteTag(reference, tag string) (err error) {
+	return r.DeleteTagContext(context.Background(), reference, tag)
+}
+
+// DeleteTagContext is like DeleteTag but binds the request to ctx.
+func (r *artifact) DeleteTagContext(ctx context.Context, reference, tag string) (err error) {
+	err = r.artifactRequest(r.client.Delete().Context(ctx), reference).
+		Suffix("tags", tag).
+		Do().
+		Error()
+	return
+}
+
+// CopyFrom copies the source artifact, possibly from another project, into the
+// repository on the server side and returns the location of the created artifact.
+func (r *artifact) CopyFrom(source model.ArtifactReference) (location string, err error) {
+	return r.CopyFromContext(context.Background(), source)
+}
+
+// CopyFromContext is like CopyFrom but binds the request to ctx.
+func (r *artifact) CopyFromContext(ctx context.Context, source model.ArtifactReference) (location string, err error) {
+	result := r.client.Post().
+		Context(ctx).
+		Project(r.project).
+		Resource("repositories").
+		Name(escapeRepository(r.repository)).
+		Suffix("artifacts").
+		Param("from", source.String()).
+		Do()
+	if err = result.Error(); err != nil {
+		return "", err
+	}
+	return result.Location(), nil
+}
+
+// AddLabel attaches the label labelID to the artifact reference.
+func (r *artifact) AddLabel(reference string, labelID int64) (err error) {
+	return r.AddLabelContext(context.Background(), reference, labelID)
+}
+
+// AddLabelContext is like AddLabel but binds the request to ctx.
+func (r *artifact) AddLabelContext(ctx context.Context, reference string, labelID int64) (err error) {
+	err = r.artifactRequest(r.client.Post().Context(ctx), reference).
+		Suffix("labels").
+		Body(map[string]int64{"id": labelID}).
+		Do().
+		Error()
+	return
+}
+
+// RemoveLabel detaches the label labelID from the artifact reference.
+func (r *artifact) RemoveLabel(reference string, labelID int64) (err error) {
+	return r.RemoveLabelContext(context.Background(), reference, labelID)
+}
+
+// RemoveLabelContext is like RemoveLabel but binds the request to ctx.
+func (r *artifact) RemoveLabelContext(ctx context.Context, reference string, labelID int64) (err error) {
+	err = r.artifactRequest(r.client.Delete().Context(ctx), reference).
+		Suffix("labels", strconv.FormatInt(labelID, 10)).
+		Do().
+		Error()
+	return
+}
+
+// Scan starts scanning the artifact reference for vulnerabilities. The scan runs
+// asynchronously, its results are read with Vulnerabilities.
+func (r *artifact) Scan(reference string) (err error) {
+	return r.ScanContext(context.Background(), reference)
+}
+
+// ScanContext is like Scan but binds the request to ctx.
+func (r *artifact) ScanContext(ctx context.Context, reference string) (err error) {
+	err = r.artifactRequest(r.client.Post().Context(ctx), reference).
+		Suffix("scan").
+		Do().
+		Error()
+	return
+}
+
+// StopScan stops the running scan of the artifact reference.
+func (r *artifact) StopScan(reference string) (err error) {
+	return r.StopScanContext(context.Background(), reference)
+}
+
+// StopScanContext is like StopScan but binds the request to ctx.
+func (r *artifact) StopScanContext(ctx context.Context, reference string) (err error) {
+	err = r.artifactRequest(r.client.Post().Context(ctx), reference).
+		Suffix("scan", "stop").
+		Do().
+		Error()
+	return
+}
+
+// ScanLog returns the log of the scan that produced the report reportID.
+func (r *artifact) ScanLog(reference, reportID string) (log string, err error) {
+	return r.ScanLogContext(context.Background(), reference, reportID)
+}
+
+// ScanLogContext is like ScanLog but binds the request to ctx.
+func (r *artifact) ScanLogContext(ctx context.Context, reference, reportID string) (log string, err error) {
+	body, err := r.artifactRequest(r.client.Get().Context(ctx), reference).
+		Suffix("scan", reportID, "log").
+		DoRaw()
+	return string(body), err
+}
+
+// Vulnerabilities returns the vulnerability reports of the artifact reference keyed
+// by mime type, empty if the artifact has not been scanned yet.
+func (r *artifact) Vulnerabilities(reference string) (result model.VulnerabilityReports, err error) {
+	return r.VulnerabilitiesContext(context.Background(), reference)
+}
+
+// VulnerabilitiesContext is like Vulnerabilities but binds the request to ctx.
+func (r *artifact) VulnerabilitiesContext(ctx context.Context, reference string) (result model.VulnerabilityReports, err error) {
+	result = model.VulnerabilityReports{}
+	err = r.artifactRequest(r.client.Get().Context(ctx), reference).
+		SetHeader(headerAcceptVulnerabilities, model.MimeTypeGenericVulnerabilityReport+", "+model.MimeTypeNativeReport).
+		Suffix("additions", "vulnerabilities").
+		Do().
+		Into(&result)
+	return
+}
+
+// Addition returns the addition kind of the artifact reference, one of the
+// model.AdditionType constants.
+func (r *artifact) Addition(reference, kind string) (result *model.Addition, err error) {
+	return r.AdditionContext(context.Background(), reference, kind)
+}
+
+// AdditionContext is like Addition but binds the request to ctx.
+func (r *artifact) AdditionContext(ctx context.Context, reference, kind string) (result *model.Addition, err error) {
+	return getAddition(kind, r.artifactRequest(r.client.Get().Context(ctx), reference).
+		Suffix("additions", kind))
+}
+
+// AdditionByLink follows link, one of the Artifact.AdditionLinks, and returns the
+// addition kind it points at. Relative links are resolved against the Harbor host,
+// absolute ones must point at it. A nil link, such as the AdditionLinks entry of
+// a kind the artifact does not have, is an error.
+func (r *artifact) AdditionByLink(kind string, link *model.AdditionLink) (result *model.Addition, err error) {
+	return r.AdditionByLinkContext(context.Background(), kind, link)
+}
+
+// AdditionByLinkContext is like AdditionByLink but binds the request to ctx.
+func (r *artifact) AdditionByLinkContext(ctx context.Context, kind string, link *model.AdditionLink) (result *model.Addition, err error) {
+	if link == nil {
+		return nil, fmt.Errorf("the artifact has no %q addition link", kind)
+	}
+	href := link.HREF
+	if !link.Absolute && !strings.HasPrefix(href, "/") {
+		href = "/" + href
+	}
+	return getAddition(kind, r.client.Get().Context(ctx).FollowLink(href))
+}
+
+func getAddition(kind string, req *rest2.Request) (*model.Addition, error) {
+	result := req.Do()
+	content, err := result.Raw()
+	if err != nil {
+		return nil, err
+	}
+	return &model.Addition{Type: kind, ContentType: result.ContentType(), Content: content}, nil
+}
+
+// Accessories lists the accessories of the artifact reference, query.Q filters them,
+// e.g. by "type=signature.cosign".
+func (r *artifact) Accessories(reference string, query *model.Query) (result *[]model.Accessory, err error) {
+	return r.AccessoriesContext(context.Background(), reference, query)
+}
+
+// AccessoriesContext is like Accessories but binds the request to ctx.
+func (r *artifact) AccessoriesContext(ctx context.Context, reference string, query *model.Query) (result *[]model.Accessory, err error) {
+	result = &[]model.Accessory{}
+	err = r.artifactRequest(r.client.Get().Context(ctx), reference).
+		Suffix("accessories").
+		Params(*query).
+		Do().
+		Into(result)
+	return
+}
+
+// AccessoriesPager returns a Pager walking every accessory of the artifact reference matching query.
+func (r *artifact) AccessoriesPager(ctx context.Context, reference string, query *model.Query) *rest2.Pager[model.Accessory] {
+	return rest2.NewPager[model.Accessory](ctx, func() *rest2.Request {
+		return r.artifactRequest(r.client.Get(), reference).
+			Suffix("accessories").
+			Params(*query)
+	})
+}
+
+// Referrers looks the manifests referring to the manifest digest up through the OCI
+// distribution referrers API of the registry. A non-empty artifactType only returns
+// the referrers of that type, e.g. "application/vnd.dev.cosign.artifact.sig.v1+json".
+func (r *artifact) Referrers(digest, artifactType string) (result *model.ReferrersIndex, err error) {
+	return r.ReferrersContext(context.Background(), digest, artifactType)
+}
+
+// ReferrersContext is like Referrers but binds the request to ctx.
+func (r *artifact) ReferrersContext(ctx context.Context, digest, artifactType string) (result *model.ReferrersIndex, err error) {
+	req := r.client.Get().
+		Context(ctx).
+		AbsPath("v2", r.project, r.repository, "referrers", digest).
+		SetHeader("Accept", model.MediaTypeOCIImageIndex)
+	if len(artifactType) > 0 {
+		req.Param("artifactType", artifactType)
+	}
+	result = &model.ReferrersIndex{}
+	err = req.Do().Into(result)
+	return
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package project
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	"github.com/TimeBye/go-harbor/pkg/project/options"
+	"github.com/TimeBye/go-harbor/pkg/rest/resttest"
+)
+
+const testDigest = "sha256:3e4c0ec8b5bbd23a2ad3f2cb3d9ac44a7ce0c5c0fac3b9a61d6a2fdea4bb5e1f"
+
+func newTestArtifacts(t *testing.T, repository string) (*artifact, *resttest.Server) {
+	client, server := newTestClient(t)
+	return client.Repositories("library").Artifacts(repository), server
+}
+
+func TestArtifactTags(t *testing.T) {
+	artifacts, server := newTestArtifacts(t, "team/app/web")
+	tags := "/projects/library/repositories/team%252Fapp%252Fweb/artifacts/" + testDigest + "/tags"
+
+	server.Reply(http.MethodGet, tags, http.StatusOK, `[{"id":1,"name":"v1","immutable":true,"signed":false}]`)
+	result, err := artifacts.ListTags(testDigest, &options.TagsListOptions{Query: &model.Query{}, WithImmutableStatus: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list := *result; len(list) != 1 || list[0].Name != "v1" || !list[0].Immutable {
+		t.Errorf("unexpected tags %+v", list)
+	}
+	if server.Last().Query.Get("with_immutable_status") != "true" {
+		t.Errorf("unexpected query %v", server.Last().Query)
+	}
+
+	server.Reply(http.MethodPost, tags, http.StatusCreated, "")
+	if err := artifacts.CreateTag(testDigest, "v2"); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"name":"v2"}` {
+		t.Errorf("unexpected tag body %s", body)
+	}
+
+	server.Reply(http.MethodDelete, tags+"/v2", http.StatusOK, "")
+	if err := artifacts.DeleteTag(testDigest, "v2"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestArtifactCopyFrom(t *testing.T) {
+	artifacts, server := newTestArtifacts(t, "team/web")
+	server.Handle(http.MethodPost, "/projects/library/repositories/team%252Fweb/artifacts", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Location", "/api/v2.0/projects/library/repositories/team%252Fweb/artifacts/"+testDigest)
+		w.WriteHeader(http.StatusCreated)
+	})
+	source, err := model.ParseArtifactReference("base/nginx:1.19@" + testDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	location, err := artifacts.CopyFrom(*source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from := server.Last().Query.Get("from"); from != "base/nginx@"+testDigest {
+		t.Errorf("unexpected from %q", from)
+	}
+	if location != "/api/v2.0/projects/library/repositories/team%252Fweb/artifacts/"+testDigest {
+		t.Errorf("unexpected location %q", location)
+	}
+}
+
+func TestArtifactLabels(t *testing.T) {
+	artifacts, server := newTestArtifacts(t, "team/web")
+	labels := "/projects/library/repositories/team%252Fweb/artifacts/v1/labels"
+
+	server.Reply(http.MethodPost, labels, http.StatusOK, "")
+	if err := artifacts.AddLabel("v1", 3); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"id":3}` {
+		t.Errorf("unexpected label body %s", body)
+	}
+
+	server.Reply(http.MethodDelete, labels+"/3", http.StatusOK, "")
+	if err := artifacts.RemoveLabel("v1", 3); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestArtifactScan(t *testing.T) {
+	artifacts, server := newTestArtifacts(t, "team/web")
+	artifact := "/projects/library/repositories/team%252Fweb/artifacts/" + testDigest
+
+	server.Reply(http.MethodPost, artifact+"/scan", http.StatusAccepted, "")
+	if err := artifacts.Scan(testDigest); err != nil {
+		t.Fatal(err)
+	}
+	server.Reply(http.MethodPost, artifact+"/scan/stop", http.StatusAccepted, "")
+	if err := artifacts.StopScan(testDigest); err != nil {
+		t.Fatal(err)
+	}
+
+	server.Handle(http.MethodGet, artifact+"/scan/8d7c3b1a/log", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("2024-04-08T02:00:03Z [INFO] Report generated\n"))
+	})
+	log, err := artifacts.ScanLog(testDigest, "8d7c3b1a")
+	if err != nil || log != "2024-04-08T02:00:03Z [INFO] Report generated\n" {
+		t.Errorf("unexpected log %q: %v", log, err)
+	}
+}
+
+func TestArtifactVulnerabilities(t *testing.T) {
+	artifacts, server := newTestArtifacts(t, "team/web")
+	server.Reply(http.MethodGet, "/projects/library/repositories/team%252Fweb/artifacts/v1/additions/vulnerabilities", http.StatusOK,
+		`{"application/vnd.security.vulnerability.report; version=1.1":{"severity":"Critical","vulnerabilities":[{"id":"CVE-2023-45853","severity":"Critical"}]}}`)
+
+	reports, err := artifacts.Vulnerabilities("v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report := reports.Report(); report == nil || report.Severity != model.VulnerabilitySeverityCritical || len(report.Vulnerabilities) != 1 {
+		t.Errorf("unexpected reports %+v", reports)
+	}
+	want := model.MimeTypeGenericVulnerabilityReport + ", " + model.MimeTypeNativeReport
+	if accept := server.Last().Header.Get("X-Accept-Vulnerabilities"); accept != want {
+		t.Errorf("unexpected X-Accept-Vulnerabilities %q", accept)
+	}
+}
+
+func TestArtifactAccessories(t *testing.T) {
+	artifacts, server := newTestArtifacts(t, "team/web")
+	server.Reply(http.MethodGet, "/projects/library/repositories/team%252Fweb/artifacts/v1/accessories", http.StatusOK,
+		`[{"id":12,"artifact_id":31,"subject_artifact_id":30,"subject_artifact_repo":"library/team/web","subject_artifact_digest":"`+testDigest+`","type":"signature.cosign","digest":"sha256:aa"}]`)
+
+	accessories, err := artifacts.Accessories("v1", &model.Query{Q: "type=signature.cosign", Page: 2, PageSize: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*accessories) != 1 || !(*accessories)[0].IsSignature() || (*accessories)[0].SubjectArtifactDigest != testDigest {
+		t.Errorf("unexpected accessories %+v", *accessories)
+	}
+	query := server.Last().Query
+	if query.Get("q") != "type=signature.cosign" || query.Get("page") != "2" || query.Get("page_size") != "5" {
+		t.Errorf("unexpected query %v", query)
+	}
+}
+
+func TestArtifactReferrers(t *testing.T) {
+	artifacts, server := newTestArtifacts(t, "team/web")
+	server.HandleAbs(http.MethodGet, "/v2/library/team/web/referrers/"+testDigest, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", model.MediaTypeOCIImageIndex)
+		w.Write([]byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"sha256:bb","size":1024,"artifactType":"application/spdx+json"}]}`))
+	})
+
+	index, err := artifacts.Referrers(testDigest, "application/spdx+json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(index.Manifests) != 1 || index.Manifests[0].ArtifactType != "application/spdx+json" {
+		t.Errorf("unexpected index %+v", index)
+	}
+	last := server.Last()
+	if accept := last.Header.Get("Accept"); accept != model.MediaTypeOCIImageIndex {
+		t.Errorf("unexpected Accept %q", accept)
+	}
+	if got := last.Query.Get("artifactType"); got != "application/spdx+json" {
+		t.Errorf("unexpected artifactType %q", got)
+	}
+
+	if _, err := artifacts.Referrers(testDigest, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := server.Last().Query["artifactType"]; ok {
+		t.Error("expected no artifactType filter")
+	}
+}
+
+func TestArtifactAdditionByLink(t *testing.T) {
+	artifacts, server := newTestArtifacts(t, "charts/nginx")
+	server.Handle(http.MethodGet, "/projects/library/repositories/charts%252Fnginx/artifacts/"+testDigest+"/additions/values.yaml", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte("replicaCount: 1\n"))
+	})
+
+	link := &model.AdditionLink{HREF: "/api/v2.0/projects/library/repositories/charts%252Fnginx/artifacts/" + testDigest + "/additions/values.yaml"}
+	addition, err := artifacts.AdditionByLink(model.AdditionTypeValuesYAML, link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values, err := addition.Values(); err != nil || values != "replicaCount: 1\n" {
+		t.Errorf("unexpected values %q: %v", values, err)
+	}
+
+	links := map[string]*model.AdditionLink{model.AdditionTypeValuesYAML: link}
+	if _, err := artifacts.AdditionByLink(model.AdditionTypeReadme, links[model.AdditionTypeReadme]); err == nil {
+		t.Error("expected an error for a missing addition link")
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
+)
+
+// ImmutableRulesInterface manages the immutable tag rules of a single project.
+type ImmutableRulesInterface interface {
+	List(query *model.Query) (results *[]model.ImmutableRule, err error)
+	ListPager(ctx context.Context, query *model.Query) *rest2.Pager[model.ImmutableRule]
+	Create(rule *model.ImmutableRule) (id int64, err error)
+	Update(id int64, rule *model.ImmutableRule) (err error)
+	SetDisabled(id int64, disabled bool) (err error)
+	Ensure(rule *model.ImmutableRule) (id int64, err error)
+	Delete(id int64) (err error)
+	ListContext(ctx context.Context, query *model.Query) (results *[]model.ImmutableRule, err error)
+	CreateContext(ctx context.Context, rule *model.ImmutableRule) (id int64, err error)
+	UpdateContext(ctx context.Context, id int64, rule *model.ImmutableRule) (err error)
+	SetDisabledContext(ctx context.Context, id int64, disabled bool) (err error)
+	EnsureContext(ctx context.Context, rule *model.ImmutableRule) (id int64, err error)
+	DeleteContext(ctx context.Context, id int64) (err error)
+}
+
+type immutableRules struct {
+	client  rest2.Interface
+	project string
+}
+
+// newImmutableRules returns an immutable tag rules client for the project
+func newImmutableRules(c *ProjectsV2Client, project string) *immutableRules {
+	return &immutableRules{
+		client:  c.RESTClient(),
+		project: project,
+	}
+}
+
+// List lists the immutable tag rules of the project.
+func (i *immutableRules) List(query *model.Query) (results *[]model.ImmutableRule, err error) {
+	return i.ListContext(context.Background(), query)
+}
+
+// ListContext is like List but binds the request to ctx.
+func (i *immutableRules) ListContext(ctx context.Context, query *model.Query) (results *[]model.ImmutableRule, err error) {
+	results = &[]model.ImmutableRule{}
+	err = byName(i.client.List().Context(ctx), i.project).
+		Suffix("immutabletagrules").
+		Params(*query).
+		Do().
+		Into(results)
+	return
+}
+
+// ListPager returns a Pager walking every immutable tag rule of the project.
+func (i *immutableRules) ListPager(ctx context.Context, query *model.Query) *rest2.Pager[model.ImmutableRule] {
+	return rest2.NewPager[model.ImmutableRule](ctx, func() *rest2.Request {
+		return byName(i.client.List(), i.project).
+			Suffix("immutabletagrules").
+			Params(*query)
+	})
+}
+
+// Create adds an immutable tag rule to the project and returns its ID, see
+// model.NewImmutableRule.
+func (i *immutableRules) Create(rule *model.ImmutableRule) (id int64, err error) {
+	return i.CreateContext(context.Background(), rule)
+}
+
+// CreateContext is like Create but binds the request to ctx.
+func (i *immutableRules) CreateContext(ctx context.Context, rule *model.ImmutableRule) (id int64, err error) {
+	return byName(i.client.Post().Context(ctx), i.project).
+		Suffix("immutabletagrules").
+		Body(rule).
+		Do().
+		CreatedID()
+}
+
+// Update replaces the selectors of the rule id. Harbor ignores the selectors when
+// rule also changes whether the rule is disabled, use SetDisabled for that.
+func (i *immutableRules) Update(id int64, rule *model.ImmutableRule) (err error) {
+	return i.UpdateContext(context.Background(), id, rule)
+}
+
+// UpdateContext is like Update but binds the request to ctx.
+func (i *immutableRules) UpdateContext(ctx context.Context, id int64, rule *model.ImmutableRule) (err error) {
+	body := *rule
+	body.ID = id
+	return byName(i.client.Put().Context(ctx), i.project).
+		Suffix("immutabletagrules", strconv.FormatInt(id, 10)).
+		Body(&body).
+		Do().
+		Error()
+}
+
+// SetDisabled disables or enables the rule id.
+func (i *immutableRules) SetDisabled(id int64, disabled bool) (err error) {
+	return i.SetDisabledContext(context.Background(), id, disabled)
+}
+
+// SetDisabledContext is like SetDisabled but binds the requests to ctx.
+func (i *immutableRules) SetDisabledContext(ctx context.Context, id int64, disabled bool) (err error) {
+	// Harbor has no endpoint returning a single rule, and updating a rule with
+	// an unchanged Disabled would overwrite its selectors: look it up first.
+	rules, err := i.ListPager(ctx, &model.Query{}).All()
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if rule.ID != id {
+			continue
+		}
+		if rule.Disabled == disabled {
+			return nil
+		}
+		rule.Disabled = disabled
+		return i.UpdateContext(ctx, id, &rule)
+	}
+	return fmt.Errorf("project %q has no immutable tag rule %d", i.project, id)
+}
+
+// Ensure makes sure the project has an enabled rule with the selectors of rule,
+// creating or enabling it as needed, and returns its ID.
+func (i *immutableRules) Ensure(rule *model.ImmutableRule) (id int64, err error) {
+	return i.EnsureContext(context.Background(), rule)
+}
+
+// EnsureContext is like Ensure but binds the requests to ctx.
+func (i *immutableRules) EnsureContext(ctx context.Context, rule *model.ImmutableRule) (id int64, err error) {
+	rules, err := i.ListPager(ctx, &model.Query{}).All()
+	if err != nil {
+		return 0, err
+	}
+	for _, existing := range rules {
+		if !existing.SameSelectors(rule) {
+			continue
+		}
+		if existing.Disabled {
+			existing.Disabled = false
+			if err := i.UpdateContext(ctx, existing.ID, &existing); err != nil {
+				return 0, err
+			}
+		}
+		return existing.ID, nil
+	}
+	enabled := *rule
+	enabled.Disabled = false
+	return i.CreateContext(ctx, &enabled)
+}
+
+// Delete removes the rule id from the project.
+func (i *immutableRules) Delete(id int64) (err error) {
+	return i.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext is like Delete but binds the request to ctx.
+func (i *immutableRules) DeleteContext(ctx context.Context, id int64) (err error) {
+	return byName(i.client.Delete().Context(ctx), i.project).
+		Suffix("immutabletagrules", strconv.FormatInt(id, 10)).
+		Do().
+		Error()
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package project
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+)
+
+const immutableRulesPath = "/projects/library/immutabletagrules"
+
+// immutableRuleJSON is a rule making the "v*" tags of every repository immutable, as listed by Harbor.
+func immutableRuleJSON(id int64, disabled bool) string {
+	return fmt.Sprintf(`{"id":%d,"project_id":1,"disabled":%t,"action":"immutable","template":"immutable_template",`+
+		`"tag_selectors":[{"kind":"doublestar","decoration":"matches","pattern":"v*"}],`+
+		`"scope_selectors":{"repository":[{"kind":"doublestar","decoration":"repoMatches","pattern":"**"}]}}`, id, disabled)
+}
+
+func versionsImmutable() *model.ImmutableRule {
+	return model.NewImmutableRule(model.ImmutableRepositories("**", false), model.ImmutableTags("v*", false))
+}
+
+func TestImmutableRulesEnsure(t *testing.T) {
+	t.Run("missing", func(t *testing.T) {
+		client, server := newTestClient(t)
+		server.Reply(http.MethodGet, immutableRulesPath, http.StatusOK, `[]`)
+		server.ReplyCreated(http.MethodPost, immutableRulesPath, "5")
+
+		id, err := client.ImmutableRules("library").Ensure(versionsImmutable())
+		if err != nil || id != 5 {
+			t.Fatalf("unexpected id %d: %v", id, err)
+		}
+		created := &model.ImmutableRule{}
+		server.Last().Decode(t, created)
+		if created.Disabled || !created.SameSelectors(versionsImmutable()) {
+			t.Errorf("unexpected body %s", server.Last().Body)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		client, server := newTestClient(t)
+		server.Reply(http.MethodGet, immutableRulesPath, http.StatusOK, "["+immutableRuleJSON(3, true)+"]")
+		server.Reply(http.MethodPut, immutableRulesPath+"/3", http.StatusOK, "")
+
+		id, err := client.ImmutableRules("library").Ensure(versionsImmutable())
+		if err != nil || id != 3 {
+			t.Fatalf("unexpected id %d: %v", id, err)
+		}
+		updated := &model.ImmutableRule{}
+		server.Last().Decode(t, updated)
+		if server.Last().Method != http.MethodPut || updated.Disabled || updated.ID != 3 || !updated.SameSelectors(versionsImmutable()) {
+			t.Errorf("unexpected %s %s", server.Last().Method, server.Last().Body)
+		}
+	})
+
+	t.Run("null selectors", func(t *testing.T) {
+		client, server := newTestClient(t)
+		server.Reply(http.MethodGet, immutableRulesPath, http.StatusOK, `[{"id":2,"disabled":false,"tag_selectors":[null],"scope_selectors":{"repository":[null]}}]`)
+		server.ReplyCreated(http.MethodPost, immutableRulesPath, "5")
+
+		if id, err := client.ImmutableRules("library").Ensure(versionsImmutable()); err != nil || id != 5 {
+			t.Fatalf("unexpected id %d: %v", id, err)
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		client, server := newTestClient(t)
+		server.Reply(http.MethodGet, immutableRulesPath, http.StatusOK, "["+immutableRuleJSON(3, false)+"]")
+
+		id, err := client.ImmutableRules("library").Ensure(versionsImmutable())
+		if err != nil || id != 3 {
+			t.Fatalf("unexpected id %d: %v", id, err)
+		}
+		if requests := server.Requests(); len(requests) != 1 {
+			t.Errorf("expected only the listing, got %d requests", len(requests))
+		}
+	})
+}
+
+func TestImmutableRulesSetDisabled(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodGet, immutableRulesPath, http.StatusOK, "["+immutableRuleJSON(3, false)+"]")
+	server.Reply(http.MethodPut, immutableRulesPath+"/3", http.StatusOK, "")
+	rules := client.ImmutableRules("library")
+
+	if err := rules.SetDisabled(3, true); err != nil {
+		t.Fatal(err)
+	}
+	updated := &model.ImmutableRule{}
+	server.Last().Decode(t, updated)
+	if !updated.Disabled || !updated.SameSelectors(versionsImmutable()) {
+		t.Errorf("expected only Disabled to change, got %s", server.Last().Body)
+	}
+
+	// Already enabled: nothing to update.
+	requests := len(server.Requests())
+	if err := rules.SetDisabled(3, false); err != nil {
+		t.Fatal(err)
+	}
+	if len(server.Requests()) != requests+1 {
+		t.Errorf("expected only the listing")
+	}
+
+	if err := rules.SetDisabled(4, true); err == nil {
+		t.Error("expected an error for an unknown rule")
+	}
+	if last := server.Last(); last.Method != http.MethodGet {
+		t.Errorf("expected no update of an unknown rule, got %s %s", last.Method, last.Path)
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package project
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	"github.com/TimeBye/go-harbor/pkg/project/options"
+	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
+)
+
+// MembersInterface manages the users and groups of a single project.
+type MembersInterface interface {
+	List(query *options.MembersListOptions) (result *[]model.ProjectMemberEntity, err error)
+	ListPager(ctx context.Context, query *options.MembersListOptions) *rest2.Pager[model.ProjectMemberEntity]
+	Get(id int64) (result *model.ProjectMemberEntity, err error)
+	Create(member *model.ProjectMember) (id int64, err error)
+	AddUser(username string, role model.ProjectRole) (id int64, err error)
+	AddGroup(group *model.UserGroup, role model.ProjectRole) (id int64, err error)
+	Update(id int64, role model.ProjectRole) (err error)
+	Delete(id int64) (err error)
+	ListContext(ctx context.Context, query *options.MembersListOptions) (result *[]model.ProjectMemberEntity, err error)
+	GetContext(ctx context.Context, id int64) (result *model.ProjectMemberEntity, err error)
+	CreateContext(ctx context.Context, member *model.ProjectMember) (id int64, err error)
+	AddUserContext(ctx context.Context, username string, role model.ProjectRole) (id int64, err error)
+	AddGroupContext(ctx context.Context, group *model.UserGroup, role model.ProjectRole) (id int64, err error)
+	UpdateContext(ctx context.Context, id int64, role model.ProjectRole) (err error)
+	DeleteContext(ctx context.Context, id int64) (err error)
+}
+
+type members struct {
+	client  rest2.Interface
+	project string
+}
+
+// newMembers returns a members client for the project
+func newMembers(c *ProjectsV2Client, project string) *members {
+	return &members{
+		client:  c.RESTClient(),
+		project: project,
+	}
+}
+
+// List lists the members of the project, query.EntityName filters them by user or group name.
+func (m *members) List(query *options.MembersListOptions) (result *[]model.ProjectMemberEntity, err error) {
+	return m.ListContext(context.Background(), query)
+}
+
+// ListContext is like List but binds the request to ctx.
+func (m *members) ListContext(ctx context.Context, query *options.MembersListOptions) (result *[]model.ProjectMemberEntity, err error) {
+	result = &[]model.ProjectMemberEntity{}
+	err = byName(m.client.Get().Context(ctx), m.project).
+		Suffix("members").
+		Params(*query).
+		Do().
+		Into(result)
+	return
+}
+
+// ListPager returns a Pager walking every member of the project matching query.
+func (m *members) ListPager(ctx context.Context, query *options.MembersListOptions) *rest2.Pager[model.ProjectMemberEntity] {
+	return rest2.NewPager[model.ProjectMemberEntity](ctx, func() *rest2.Request {
+		return byName(m.client.Get(), m.project).
+			Suffix("members").
+			Params(*query)
+	})
+}
+
+func (m *members) Get(id int64) (result *model.ProjectMemberEntity, err error) {
+	return m.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (m *members) GetContext(ctx context.Context, id int64) (result *model.ProjectMemberEntity, err error) {
+	result = &model.ProjectMemberEntity{}
+	err = byName(m.client.Get().Context(ctx), m.project).
+		Suffix("members", strconv.FormatInt(id, 10)).
+		Do().
+		Into(result)
+	return
+}
+
+// Create adds a user or a group to the project and returns the ID of the membership.
+func (m *members) Create(member *model.ProjectMember) (id int64, err error) {
+	return m.CreateContext(context.Background(), member)
+}
+
+// CreateContext is like Create but binds the request to ctx.
+func (m *members) CreateContext(ctx context.Context, member *model.ProjectMember) (id int64, err error) {
+	return byName(m.client.Post().Context(ctx), m.project).
+		Suffix("members").
+		Body(member).
+		Do().
+		CreatedID()
+}
+
+// AddUser adds the user called username to the project with the given role.
+func (m *members) AddUser(username string, role model.ProjectRole) (id int64, err error) {
+	return m.AddUserContext(context.Background(), username, role)
+}
+
+// AddUserContext is like AddUser but binds the request to ctx.
+func (m *members) AddUserContext(ctx context.Context, username string, role model.ProjectRole) (id int64, err error) {
+	return m.CreateContext(ctx, &model.ProjectMember{
+		RoleID:     role,
+		MemberUser: &model.UserEntity{Username: username},
+	})
+}
+
+// AddGroup adds a group to the project with the given role. The group is referenced by
+// ID if set, otherwise by name and type, or by LDAP DN for LDAP groups.
+func (m *members) AddGroup(group *model.UserGroup, role model.ProjectRole) (id int64, err error) {
+	return m.AddGroupContext(context.Background(), group, role)
+}
+
+// AddGroupContext is like AddGroup but binds the request to ctx.
+func (m *members) AddGroupContext(ctx context.Context, group *model.UserGroup, role model.ProjectRole) (id int64, err error) {
+	return m.CreateContext(ctx, &model.ProjectMember{
+		RoleID:      role,
+		MemberGroup: group,
+	})
+}
+
+// Update changes the role of the membership id.
+func (m *members) Update(id int64, role model.ProjectRole) (err error) {
+	return m.UpdateContext(context.Background(), id, role)
+}
+
+// UpdateContext is like Update but binds the request to ctx.
+func (m *members) UpdateContext(ctx context.Context, id int64, role model.ProjectRole) (err error) {
+	err = byName(m.client.Put().Context(ctx), m.project).
+		Suffix("members", strconv.FormatInt(id, 10)).
+		Body(&model.RoleRequest{RoleID: role}).
+		Do().
+		Error()
+	return
+}
+
+// Delete removes the membership id from the project.
+func (m *members) Delete(id int64) (err error) {
+	return m.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext is like Delete but binds the request to ctx.
+func (m *members) DeleteContext(ctx context.Context, id int64) (err error) {
+	err = byName(m.client.Delete().Context(ctx), m.project).
+		Suffix("members", strconv.FormatInt(id, 10)).
+		Do().
+		Error()
+	return
+}
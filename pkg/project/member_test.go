@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package project
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	"github.com/TimeBye/go-harbor/pkg/project/options"
+)
+
+func TestMembersAdd(t *testing.T) {
+	client, server := newTestClient(t)
+	members := client.Members("library")
+	server.ReplyCreated(http.MethodPost, "/projects/library/members", "5")
+
+	id, err := members.AddUser("alice", model.Developer)
+	if err != nil || id != 5 {
+		t.Fatalf("expected ID 5, got %d, %v", id, err)
+	}
+	if body := string(server.Last().Body); body != `{"role_id":2,"member_user":{"username":"alice"}}` {
+		t.Errorf("unexpected user member body %s", body)
+	}
+
+	if _, err := members.AddGroup(&model.UserGroup{GroupName: "devs", GroupType: model.UserGroupTypeOIDC}, model.Maintainer); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"role_id":4,"member_group":{"group_name":"devs","group_type":3}}` {
+		t.Errorf("unexpected group member body %s", body)
+	}
+
+	if _, err := members.AddGroup(&model.UserGroup{LDAPGroupDN: "cn=ops,dc=example,dc=com", GroupType: model.UserGroupTypeLDAP}, model.Guest); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"role_id":3,"member_group":{"group_type":1,"ldap_group_dn":"cn=ops,dc=example,dc=com"}}` {
+		t.Errorf("unexpected LDAP group member body %s", body)
+	}
+}
+
+func TestMembersListUpdateDelete(t *testing.T) {
+	client, server := newTestClient(t)
+	members := client.Members("library")
+
+	server.Reply(http.MethodGet, "/projects/library/members", http.StatusOK,
+		`[{"id":5,"project_id":1,"entity_name":"alice","role_name":"developer","role_id":2,"entity_id":3,"entity_type":"u"},
+		  {"id":6,"project_id":1,"entity_name":"devs","role_name":"maintainer","role_id":4,"entity_id":9,"entity_type":"g"}]`)
+	result, err := members.List(&options.MembersListOptions{Query: &model.Query{}, EntityName: "al"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := server.Last().Query.Get("entityname"); name != "al" {
+		t.Errorf("unexpected entityname %q", name)
+	}
+	list := *result
+	if len(list) != 2 || list[0].RoleID != model.Developer || list[0].EntityType != model.MemberEntityUser ||
+		list[1].RoleID != model.Maintainer || list[1].EntityType != model.MemberEntityGroup {
+		t.Errorf("unexpected members %+v", list)
+	}
+
+	server.Reply(http.MethodPut, "/projects/library/members/5", http.StatusOK, "")
+	if err := members.Update(5, model.ProjectAdmin); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"role_id":1}` {
+		t.Errorf("unexpected role body %s", body)
+	}
+
+	server.Reply(http.MethodDelete, "/projects/library/members/5", http.StatusOK, "")
+	if err := members.Delete(5); err != nil {
+		t.Fatal(err)
+	}
+	if server.Last().Header.Get(headerIsResourceName) != "true" {
+		t.Errorf("expected the project to be referenced by name")
+	}
+}
+
+func TestProjectRoleString(t *testing.T) {
+	for role, name := range map[model.ProjectRole]string{
+		model.ProjectAdmin: "projectAdmin",
+		model.Developer:    "developer",
+		model.Guest:        "guest",
+		model.Maintainer:   "maintainer",
+		model.LimitedGuest: "limitedGuest",
+		0:                  "unknown",
+	} {
+		if role.String() != name {
+			t.Errorf("role %d: expected %q, got %q", role, name, role.String())
+		}
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package project
+
+import (
+	"context"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
+)
+
+// MetadataInterface manages the metadata of a single project. Typed access to the
+// well-known keys is provided by model.ProjectMetadata.
+type MetadataInterface interface {
+	List() (result model.ProjectMetadata, err error)
+	Get(key string) (value string, err error)
+	Add(metadata model.ProjectMetadata) (err error)
+	Update(key, value string) (err error)
+	Set(metadata model.ProjectMetadata) (err error)
+	Delete(key string) (err error)
+	ListContext(ctx context.Context) (result model.ProjectMetadata, err error)
+	GetContext(ctx context.Context, key string) (value string, err error)
+	AddContext(ctx context.Context, metadata model.ProjectMetadata) (err error)
+	UpdateContext(ctx context.Context, key, value string) (err error)
+	SetContext(ctx context.Context, metadata model.ProjectMetadata) (err error)
+	DeleteContext(ctx context.Context, key string) (err error)
+}
+
+type metadata struct {
+	client  rest2.Interface
+	project string
+}
+
+// newMetadata returns a metadata client for the project
+func newMetadata(c *ProjectsV2Client, project string) *metadata {
+	return &metadata{
+		client:  c.RESTClient(),
+		project: project,
+	}
+}
+
+func (m *metadata) List() (result model.ProjectMetadata, err error) {
+	return m.ListContext(context.Background())
+}
+
+// ListContext is like List but binds the request to ctx.
+func (m *metadata) ListContext(ctx context.Context) (result model.ProjectMetadata, err error) {
+	result = model.ProjectMetadata{}
+	err = byName(m.client.Get().Context(ctx), m.project).
+		Suffix("metadatas").
+		Do().
+		Into(&result)
+	return
+}
+
+// Get returns the raw value of a single metadata key.
+func (m *metadata) Get(key string) (value string, err error) {
+	return m.GetContext(context.Background(), key)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (m *metadata) GetContext(ctx context.Context, key string) (value string, err error) {
+	result := model.ProjectMetadata{}
+	err = byName(m.client.Get().Context(ctx), m.project).
+		Suffix("metadatas", key).
+		Do().
+		Into(&result)
+	return result[key], err
+}
+
+// Add adds metadata keys that the project does not carry yet.
+func (m *metadata) Add(metadata model.ProjectMetadata) (err error) {
+	return m.AddContext(context.Background(), metadata)
+}
+
+// AddContext is like Add but binds the request to ctx.
+func (m *metadata) AddContext(ctx context.Context, metadata model.ProjectMetadata) (err error) {
+	err = byName(m.client.Post().Context(ctx), m.project).
+		Suffix("metadatas").
+		Body(metadata).
+		Do().
+		Error()
+	return
+}
+
+// Update changes the value of an existing metadata key.
+func (m *metadata) Update(key, value string) (err error) {
+	return m.UpdateContext(context.Background(), key, value)
+}
+
+// UpdateContext is like Update but binds the request to ctx.
+func (m *metadata) UpdateContext(ctx context.Context, key, value string) (err error) {
+	err = byName(m.client.Put().Context(ctx), m.project).
+		Suffix("metadatas", key).
+		Body(model.ProjectMetadata{key: value}).
+		Do().
+		Error()
+	return
+}
+
+// Set writes every key of metadata, updating the keys the project already carries
+// and adding the others.
+func (m *metadata) Set(metadata model.ProjectMetadata) (err error) {
+	return m.SetContext(context.Background(), metadata)
+}
+
+// SetContext is like Set but binds the requests to ctx.
+func (m *metadata) SetContext(ctx context.Context, metadata model.ProjectMetadata) (err error) {
+	existing, err := m.ListContext(ctx)
+	if err != nil {
+		return err
+	}
+	missing := model.ProjectMetadata{}
+	for key, value := range metadata {
+		current, ok := existing[key]
+		switch {
+		case !ok:
+			missing[key] = value
+		case current != value:
+			if err := m.UpdateContext(ctx, key, value); err != nil {
+				return err
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return m.AddContext(ctx, missing)
+}
+
+// Delete removes a metadata key from the project.
+func (m *metadata) Delete(key string) (err error) {
+	return m.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is like Delete but binds the request to ctx.
+func (m *metadata) DeleteContext(ctx context.Context, key string) (err error) {
+	err = byName(m.client.Delete().Context(ctx), m.project).
+		Suffix("metadatas", key).
+		Do().
+		Error()
+	return
+}
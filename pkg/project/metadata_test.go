@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package project
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+)
+
+func TestMetadataRequests(t *testing.T) {
+	client, server := newTestClient(t)
+	metadata := client.Metadata("library")
+
+	server.Reply(http.MethodGet, "/projects/library/metadatas/auto_scan", http.StatusOK, `{"auto_scan":"true"}`)
+	value, err := metadata.Get("auto_scan")
+	if err != nil || value != "true" {
+		t.Errorf("unexpected value %q: %v", value, err)
+	}
+
+	server.Reply(http.MethodPost, "/projects/library/metadatas", http.StatusOK, "")
+	if err := metadata.Add(model.ProjectMetadata{"severity": "high"}); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"severity":"high"}` {
+		t.Errorf("unexpected add body %s", body)
+	}
+
+	server.Reply(http.MethodPut, "/projects/library/metadatas/severity", http.StatusOK, "")
+	if err := metadata.Update("severity", "critical"); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"severity":"critical"}` {
+		t.Errorf("unexpected update body %s", body)
+	}
+
+	server.Reply(http.MethodDelete, "/projects/library/metadatas/severity", http.StatusOK, "")
+	if err := metadata.Delete("severity"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, request := range server.Requests() {
+		if request.Header.Get(headerIsResourceName) != "true" {
+			t.Errorf("%s %s: expected the project to be referenced by name", request.Method, request.Path)
+		}
+	}
+}
+
+func TestMetadataSet(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodGet, "/projects/library/metadatas", http.StatusOK, `{"public":"false","auto_scan":"true"}`)
+	server.Reply(http.MethodPut, "/projects/library/metadatas/public", http.StatusOK, "")
+	server.Reply(http.MethodPost, "/projects/library/metadatas", http.StatusOK, "")
+
+	err := client.Metadata("library").Set(model.ProjectMetadata{"public": "true", "auto_scan": "true", "severity": "high"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests := server.Requests()
+	if len(requests) != 3 {
+		t.Fatalf("expected list, update and add requests, got %d", len(requests))
+	}
+	if body := string(requests[1].Body); requests[1].Method != http.MethodPut || body != `{"public":"true"}` {
+		t.Errorf("unexpected update %s %s", requests[1].Method, body)
+	}
+	if body := string(requests[2].Body); requests[2].Method != http.MethodPost || body != `{"severity":"high"}` {
+		t.Errorf("unexpected add %s %s", requests[2].Method, body)
+	}
+}
@@ -23,7 +23,8 @@ type ArtifactsListOptions struct {
 	*model.Query
 	// ProjectName The name of the project
 	ProjectName string `json:"project_name,omitempty"`
-	// The name of the repository. If it contains slash, encode it with URL encoding. e.g. a/b -> a%252Fb
+	// The name of the repository. Not used to select the repository: the artifacts are listed in the
+	// repository passed to Repository.Artifacts, whose name is escaped in the path, e.g. a/b -> a%252Fb
 	RepositoryName string `json:"repository_name,omitempty"`
 	// Specify whether the tags are included inside the returning artifacts
 	// Default value : true
@@ -40,4 +41,23 @@ type ArtifactsListOptions struct {
 	// Specify whether the immutable status is included inside the tags of the returning artifacts. Only works when setting "with_tag=true"
 	//Default value : false
 	WithImmutableStatus bool `json:"with_immutable_status,omitempty"`
+	// Specify whether the accessories are included inside the returning artifacts
+	//Default value : false
+	WithAccessory bool `json:"with_accessory,omitempty"`
+}
+
+type MembersListOptions struct {
+	*model.Query
+	// EntityName The entity name to search.
+	EntityName string `json:"entityname,omitempty"`
+}
+
+type TagsListOptions struct {
+	*model.Query
+	// Specify whether the signature is included inside the returning tags
+	//Default value : false
+	WithSignature bool `json:"with_signature,omitempty"`
+	// Specify whether the immutable status is included inside the returning tags
+	//Default value : false
+	WithImmutableStatus bool `json:"with_immutable_status,omitempty"`
 }
@@ -16,6 +16,11 @@ See the License for the specific language governing permissions and
 package project
 
 import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
 	"github.com/TimeBye/go-harbor/pkg/project/options"
 	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
 	"github.com/goharbor/harbor/src/pkg/project/models"
@@ -32,19 +37,212 @@ type ProjectsV2Client struct {
 	restClient rest2.Interface
 }
 
+const headerIsResourceName = rest2.HeaderIsResourceName
+
+// byName points r at the project called name.
+func byName(r *rest2.Request, name string) *rest2.Request {
+	return r.ProjectByName(name)
+}
+
+// byID points r at the project with the given ID.
+func byID(r *rest2.Request, id int64) *rest2.Request {
+	return r.SetHeader(headerIsResourceName, "false").
+		Resource("projects").
+		Name(strconv.FormatInt(id, 10))
+}
+
 func (p *ProjectsV2Client) Get(name string) (result *models.Project, err error) {
+	return p.GetContext(context.Background(), name)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (p *ProjectsV2Client) GetContext(ctx context.Context, name string) (result *models.Project, err error) {
+	result = &models.Project{}
+	err = byName(p.restClient.Get().Context(ctx), name).
+		Do().
+		Into(result)
+	return
+}
+
+func (p *ProjectsV2Client) GetByID(id int64) (result *models.Project, err error) {
+	return p.GetByIDContext(context.Background(), id)
+}
+
+// GetByIDContext is like GetByID but binds the request to ctx.
+func (p *ProjectsV2Client) GetByIDContext(ctx context.Context, id int64) (result *models.Project, err error) {
 	result = &models.Project{}
-	err = p.restClient.Get().
+	err = byID(p.restClient.Get().Context(ctx), id).
+		Do().
+		Into(result)
+	return
+}
+
+// Exists reports whether a project called name exists.
+func (p *ProjectsV2Client) Exists(name string) (exists bool, err error) {
+	return p.ExistsContext(context.Background(), name)
+}
+
+// ExistsContext is like Exists but binds the request to ctx.
+func (p *ProjectsV2Client) ExistsContext(ctx context.Context, name string) (exists bool, err error) {
+	err = p.restClient.Head().
+		Context(ctx).
 		Resource("projects").
-		Name(name).
+		Param("project_name", name).
+		Do().
+		Error()
+	switch {
+	case err == nil:
+		return true, nil
+	case rest2.StatusCode(err) == http.StatusNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// Create creates a project and returns its ID.
+func (p *ProjectsV2Client) Create(project *model.ProjectReq) (id int64, err error) {
+	return p.CreateContext(context.Background(), project)
+}
+
+// CreateContext is like Create but binds the request to ctx.
+func (p *ProjectsV2Client) CreateContext(ctx context.Context, project *model.ProjectReq) (id int64, err error) {
+	return p.restClient.Post().
+		Context(ctx).
+		Resource("projects").
+		Body(project).
+		Do().
+		CreatedID()
+}
+
+// Update updates the project called name with the fields set in project.
+func (p *ProjectsV2Client) Update(name string, project *model.ProjectReq) (err error) {
+	return p.UpdateContext(context.Background(), name, project)
+}
+
+// UpdateContext is like Update but binds the request to ctx.
+func (p *ProjectsV2Client) UpdateContext(ctx context.Context, name string, project *model.ProjectReq) (err error) {
+	err = byName(p.restClient.Put().Context(ctx), name).
+		Body(project).
+		Do().
+		Error()
+	return
+}
+
+// CVEAllowlist returns the CVE allowlist of the project called name.
+func (p *ProjectsV2Client) CVEAllowlist(name string) (result *model.CVEAllowlist, err error) {
+	return p.CVEAllowlistContext(context.Background(), name)
+}
+
+// CVEAllowlistContext is like CVEAllowlist but binds the request to ctx.
+func (p *ProjectsV2Client) CVEAllowlistContext(ctx context.Context, name string) (result *model.CVEAllowlist, err error) {
+	project := &struct {
+		CVEAllowlist *model.CVEAllowlist `json:"cve_allowlist"`
+	}{CVEAllowlist: &model.CVEAllowlist{}}
+	err = byName(p.restClient.Get().Context(ctx), name).
+		Do().
+		Into(project)
+	return project.CVEAllowlist, err
+}
+
+// SetCVEAllowlist replaces the CVE allowlist of the project called name. It only
+// applies once the project stops reusing the system allowlist, see
+// model.ProjectMetadata.SetReuseSysCVEAllowlist.
+func (p *ProjectsV2Client) SetCVEAllowlist(name string, allowlist *model.CVEAllowlist) (err error) {
+	return p.SetCVEAllowlistContext(context.Background(), name, allowlist)
+}
+
+// SetCVEAllowlistContext is like SetCVEAllowlist but binds the request to ctx.
+func (p *ProjectsV2Client) SetCVEAllowlistContext(ctx context.Context, name string, allowlist *model.CVEAllowlist) (err error) {
+	return p.UpdateContext(ctx, name, &model.ProjectReq{CVEAllowlist: allowlist})
+}
+
+// Summary returns the quota, repository and member statistics of the project called name.
+func (p *ProjectsV2Client) Summary(name string) (result *model.ProjectSummary, err error) {
+	return p.SummaryContext(context.Background(), name)
+}
+
+// SummaryContext is like Summary but binds the request to ctx.
+func (p *ProjectsV2Client) SummaryContext(ctx context.Context, name string) (result *model.ProjectSummary, err error) {
+	result = &model.ProjectSummary{}
+	err = byName(p.restClient.Get().Context(ctx), name).
+		Suffix("summary").
 		Do().
 		Into(result)
 	return
 }
 
+// Deletable reports whether the project called name can be deleted, i.e. holds no
+// repositories, charts or replication rules anymore.
+func (p *ProjectsV2Client) Deletable(name string) (result *model.ProjectDeletable, err error) {
+	return p.DeletableContext(context.Background(), name)
+}
+
+// DeletableContext is like Deletable but binds the request to ctx.
+func (p *ProjectsV2Client) DeletableContext(ctx context.Context, name string) (result *model.ProjectDeletable, err error) {
+	result = &model.ProjectDeletable{}
+	err = byName(p.restClient.Get().Context(ctx), name).
+		Suffix("_deletable").
+		Do().
+		Into(result)
+	return
+}
+
+// Scanner returns the scanner adapter scanning the artifacts of the project.
+func (p *ProjectsV2Client) Scanner(name string) (result *model.ScannerRegistration, err error) {
+	return p.ScannerContext(context.Background(), name)
+}
+
+// ScannerContext is like Scanner but binds the request to ctx.
+func (p *ProjectsV2Client) ScannerContext(ctx context.Context, name string) (result *model.ScannerRegistration, err error) {
+	result = &model.ScannerRegistration{}
+	err = byName(p.restClient.Get().Context(ctx), name).
+		Suffix("scanner").
+		Do().
+		Into(result)
+	return
+}
+
+// SetScanner makes the scanner adapter uuid scan the artifacts of the project.
+func (p *ProjectsV2Client) SetScanner(name, uuid string) (err error) {
+	return p.SetScannerContext(context.Background(), name, uuid)
+}
+
+// SetScannerContext is like SetScanner but binds the request to ctx.
+func (p *ProjectsV2Client) SetScannerContext(ctx context.Context, name, uuid string) (err error) {
+	err = byName(p.restClient.Put().Context(ctx), name).
+		Suffix("scanner").
+		Body(map[string]string{"uuid": uuid}).
+		Do().
+		Error()
+	return
+}
+
+// ScannerCandidates lists the scanner adapters the project may use.
+func (p *ProjectsV2Client) ScannerCandidates(name string, query *model.Query) (results *[]model.ScannerRegistration, err error) {
+	return p.ScannerCandidatesContext(context.Background(), name, query)
+}
+
+// ScannerCandidatesContext is like ScannerCandidates but binds the request to ctx.
+func (p *ProjectsV2Client) ScannerCandidatesContext(ctx context.Context, name string, query *model.Query) (results *[]model.ScannerRegistration, err error) {
+	results = &[]model.ScannerRegistration{}
+	err = byName(p.restClient.Get().Context(ctx), name).
+		Suffix("scanner", "candidates").
+		Params(*query).
+		Do().
+		Into(results)
+	return
+}
+
 func (p *ProjectsV2Client) List(query *options.ProjectsListOptions) (results *[]models.Project, err error) {
+	return p.ListContext(context.Background(), query)
+}
+
+// ListContext is like List but binds the request to ctx.
+func (p *ProjectsV2Client) ListContext(ctx context.Context, query *options.ProjectsListOptions) (results *[]models.Project, err error) {
 	results = &[]models.Project{}
 	err = p.restClient.List().
+		Context(ctx).
 		Resource("projects").
 		Params(*query).
 		Do().
@@ -52,10 +250,73 @@ func (p *ProjectsV2Client) List(query *options.ProjectsListOptions) (results *[]
 	return
 }
 
+// ListPager returns a Pager walking every project matching query.
+func (p *ProjectsV2Client) ListPager(ctx context.Context, query *options.ProjectsListOptions) *rest2.Pager[models.Project] {
+	return rest2.NewPager[models.Project](ctx, func() *rest2.Request {
+		return p.restClient.List().
+			Resource("projects").
+			Params(*query)
+	})
+}
+
+// ListAll returns every project matching query, walking all pages.
+func (p *ProjectsV2Client) ListAll(query *options.ProjectsListOptions) (results *[]models.Project, err error) {
+	return p.ListAllContext(context.Background(), query)
+}
+
+// ListAllContext is like ListAll but binds the requests to ctx.
+func (p *ProjectsV2Client) ListAllContext(ctx context.Context, query *options.ProjectsListOptions) (results *[]models.Project, err error) {
+	items, err := p.ListPager(ctx, query).All()
+	return &items, err
+}
+
+// SearchRepositories lists the repositories of every project the user can see.
+// query.Q filters them, e.g. "name=~nginx", and query.Sort orders them, e.g.
+// "-pull_count" or "-update_time".
+func (p *ProjectsV2Client) SearchRepositories(query *model.Query) (results *[]model.Repository, err error) {
+	return p.SearchRepositoriesContext(context.Background(), query)
+}
+
+// SearchRepositoriesContext is like SearchRepositories but binds the request to ctx.
+func (p *ProjectsV2Client) SearchRepositoriesContext(ctx context.Context, query *model.Query) (results *[]model.Repository, err error) {
+	results = &[]model.Repository{}
+	err = p.restClient.List().
+		Context(ctx).
+		Resource("repositories").
+		Params(*query).
+		Do().
+		Into(results)
+	return
+}
+
+// SearchRepositoriesPager returns a Pager walking every repository matching query, across projects.
+func (p *ProjectsV2Client) SearchRepositoriesPager(ctx context.Context, query *model.Query) *rest2.Pager[model.Repository] {
+	return rest2.NewPager[model.Repository](ctx, func() *rest2.Request {
+		return p.restClient.List().
+			Resource("repositories").
+			Params(*query)
+	})
+}
+
 func (p *ProjectsV2Client) Delete(name string) (err error) {
-	err = p.restClient.Delete().
-		Resource("projects").
-		Name(name).
+	return p.DeleteContext(context.Background(), name)
+}
+
+// DeleteContext is like Delete but binds the request to ctx.
+func (p *ProjectsV2Client) DeleteContext(ctx context.Context, name string) (err error) {
+	err = byName(p.restClient.Delete().Context(ctx), name).
+		Do().
+		Error()
+	return
+}
+
+func (p *ProjectsV2Client) DeleteByID(id int64) (err error) {
+	return p.DeleteByIDContext(context.Background(), id)
+}
+
+// DeleteByIDContext is like DeleteByID but binds the request to ctx.
+func (p *ProjectsV2Client) DeleteByIDContext(ctx context.Context, id int64) (err error) {
+	err = byID(p.restClient.Delete().Context(ctx), id).
 		Do().
 		Error()
 	return
@@ -73,6 +334,21 @@ func (p *ProjectsV2Client) Repositories(project string) RepositoryInterface {
 	return newRepositories(p, project)
 }
 
+// Metadata returns a client for the metadata of the project called project.
+func (p *ProjectsV2Client) Metadata(project string) MetadataInterface {
+	return newMetadata(p, project)
+}
+
+// Members returns a client for the members of the project called project.
+func (p *ProjectsV2Client) Members(project string) MembersInterface {
+	return newMembers(p, project)
+}
+
+// ImmutableRules returns a client for the immutable tag rules of the project called project.
+func (p *ProjectsV2Client) ImmutableRules(project string) ImmutableRulesInterface {
+	return newImmutableRules(p, project)
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (p *ProjectsV2Client) RESTClient() rest2.Interface {
@@ -0,0 +1,189 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package project
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
+	"github.com/TimeBye/go-harbor/pkg/rest/resttest"
+)
+
+func newTestClient(t *testing.T) (*ProjectsV2Client, *resttest.Server) {
+	server := resttest.NewServer(t)
+	client, err := NewProjectsV1Client(server.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server
+}
+
+func TestProjectExists(t *testing.T) {
+	client, server := newTestClient(t)
+	for _, tt := range []struct {
+		status int
+		exists bool
+		fails  bool
+	}{
+		{status: http.StatusOK, exists: true},
+		{status: http.StatusNotFound, exists: false},
+		{status: http.StatusInternalServerError, fails: true},
+	} {
+		server.Reply(http.MethodHead, "/projects", tt.status, "")
+		exists, err := client.Exists("library")
+		if tt.fails {
+			if rest2.StatusCode(err) != tt.status {
+				t.Errorf("%d: expected the error to be propagated, got %v", tt.status, err)
+			}
+			continue
+		}
+		if err != nil || exists != tt.exists {
+			t.Errorf("%d: expected %v, got %v, %v", tt.status, tt.exists, exists, err)
+		}
+		if name := server.Last().Query.Get("project_name"); name != "library" {
+			t.Errorf("unexpected project_name %q", name)
+		}
+	}
+}
+
+func TestProjectDeletable(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodGet, "/projects/library/_deletable", http.StatusOK, `{"deletable":false,"message":"the project contains repositories"}`)
+	result, err := client.Deletable("library")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Deletable || result.Message != "the project contains repositories" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if server.Last().Header.Get(headerIsResourceName) != "true" {
+		t.Errorf("expected the project to be referenced by name")
+	}
+}
+
+func TestProjectCreateAndUpdate(t *testing.T) {
+	client, server := newTestClient(t)
+	server.ReplyCreated(http.MethodPost, "/projects", "12")
+	limit := int64(-1)
+	id, err := client.Create(&model.ProjectReq{ProjectName: "library", StorageLimit: &limit})
+	if err != nil || id != 12 {
+		t.Fatalf("expected ID 12, got %d, %v", id, err)
+	}
+	created := map[string]interface{}{}
+	server.Last().Decode(t, &created)
+	if created["project_name"] != "library" || created["storage_limit"] != float64(-1) {
+		t.Errorf("unexpected creation body %v", created)
+	}
+
+	server.Reply(http.MethodPut, "/projects/library", http.StatusOK, "")
+	if err := client.Update("library", &model.ProjectReq{Metadata: model.ProjectMetadata{model.ProMetaPublic: "true"}}); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"metadata":{"public":"true"}}` {
+		t.Errorf("unexpected update body %s", body)
+	}
+}
+
+func TestProjectByID(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodGet, "/projects/15", http.StatusOK, `{"project_id":15,"name":"15"}`)
+	project, err := client.GetByID(15)
+	if err != nil || project.ProjectID != 15 {
+		t.Fatalf("unexpected project %+v: %v", project, err)
+	}
+	if server.Last().Header.Get(headerIsResourceName) != "false" {
+		t.Errorf("expected the project to be referenced by ID")
+	}
+
+	server.Reply(http.MethodDelete, "/projects/15", http.StatusOK, "")
+	if err := client.DeleteByID(15); err != nil {
+		t.Fatal(err)
+	}
+	if server.Last().Header.Get(headerIsResourceName) != "false" {
+		t.Errorf("expected the project to be referenced by ID")
+	}
+}
+
+func TestProjectSummary(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodGet, "/projects/library/summary", http.StatusOK, `{"repo_count":3,"developer_count":2,"quota":{"hard":{"storage":-1},"used":{"storage":1024}}}`)
+	summary, err := client.Summary("library")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.RepoCount != 3 || summary.DeveloperCount != 2 || summary.Quota == nil || summary.Quota.Used["storage"] != 1024 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+}
+
+func TestProjectScanner(t *testing.T) {
+	client, server := newTestClient(t)
+	uuid := "a8b0a1c8-9a52-11ee-b9d1-0242ac120002"
+	server.Reply(http.MethodGet, "/projects/library/scanner", http.StatusOK, `{"uuid":"`+uuid+`","name":"Trivy","url":"http://trivy-adapter:8080","is_default":true}`)
+	server.Reply(http.MethodPut, "/projects/library/scanner", http.StatusOK, "")
+	server.Reply(http.MethodGet, "/projects/library/scanner/candidates", http.StatusOK, `[{"uuid":"`+uuid+`","name":"Trivy"},{"uuid":"c0ffee","name":"Clair"}]`)
+
+	scanner, err := client.Scanner("library")
+	if err != nil || scanner.UUID != uuid || !scanner.IsDefault {
+		t.Errorf("unexpected scanner %+v: %v", scanner, err)
+	}
+	if server.Last().Header.Get(headerIsResourceName) != "true" {
+		t.Errorf("expected the project to be referenced by name")
+	}
+
+	if err := client.SetScanner("library", "c0ffee"); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"uuid":"c0ffee"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+
+	candidates, err := client.ScannerCandidates("library", &model.Query{Q: "name=~T"})
+	if err != nil || len(*candidates) != 2 {
+		t.Errorf("unexpected candidates %+v: %v", candidates, err)
+	}
+	if q := server.Last().Query.Get("q"); q != "name=~T" {
+		t.Errorf("unexpected q %q", q)
+	}
+}
+
+func TestProjectSetCVEAllowlist(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodPut, "/projects/library", http.StatusOK, "")
+
+	allowlist := &model.CVEAllowlist{Items: []model.CVEAllowlistItem{{CVEID: "CVE-2021-44228"}}}
+	if err := client.SetCVEAllowlist("library", allowlist); err != nil {
+		t.Fatal(err)
+	}
+	body := map[string]json.RawMessage{}
+	server.Last().Decode(t, &body)
+	if len(body) != 1 || body["cve_allowlist"] == nil {
+		t.Fatalf("expected only cve_allowlist in the body, got %s", server.Last().Body)
+	}
+	sent := &model.CVEAllowlist{}
+	if err := json.Unmarshal(body["cve_allowlist"], sent); err != nil {
+		t.Fatal(err)
+	}
+	if !sent.Contains("CVE-2021-44228") || sent.ExpiresAt != nil {
+		t.Errorf("unexpected allowlist %s", body["cve_allowlist"])
+	}
+	if server.Last().Header.Get(headerIsResourceName) != "true" {
+		t.Errorf("expected the project to be referenced by name")
+	}
+}
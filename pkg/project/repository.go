@@ -16,6 +16,10 @@ See the License for the specific language governing permissions and
 package project
 
 import (
+	"context"
+	"net/url"
+	"strings"
+
 	"github.com/TimeBye/go-harbor/pkg/model"
 	"github.com/TimeBye/go-harbor/pkg/project/options"
 	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
@@ -26,7 +30,14 @@ type RepositoryInterface interface {
 	List(query *options.RepositoriesListOptions) (result *[]model.Repository, err error)
 	Get(name string) (result *model.Repository, err error)
 	Delete(name string) (err error)
-	//Put()
+	ListContext(ctx context.Context, query *options.RepositoriesListOptions) (result *[]model.Repository, err error)
+	GetContext(ctx context.Context, name string) (result *model.Repository, err error)
+	DeleteContext(ctx context.Context, name string) (err error)
+	ListPager(ctx context.Context, query *options.RepositoriesListOptions) *rest2.Pager[model.Repository]
+	ListAll(query *options.RepositoriesListOptions) (result *[]model.Repository, err error)
+	ListAllContext(ctx context.Context, query *options.RepositoriesListOptions) (result *[]model.Repository, err error)
+	Update(name, description string) (err error)
+	UpdateContext(ctx context.Context, name, description string) (err error)
 }
 
 type Repository struct {
@@ -34,6 +45,16 @@ type Repository struct {
 	project string
 }
 
+// escapeRepository escapes the slashes of a repository name such as "a/b". Harbor
+// expects them encoded twice in paths, the request encodes the result once more and
+// sends "a%252Fb".
+func escapeRepository(name string) string {
+	if !strings.Contains(name, "/") {
+		return name
+	}
+	return url.PathEscape(name)
+}
+
 // newRepositories returns a ConfigMaps
 func newRepositories(
 	c *ProjectsV2Client,
@@ -45,19 +66,31 @@ func newRepositories(
 }
 
 func (r *Repository) Get(name string) (result *model.Repository, err error) {
+	return r.GetContext(context.Background(), name)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (r *Repository) GetContext(ctx context.Context, name string) (result *model.Repository, err error) {
 	result = &model.Repository{}
 	err = r.client.Get().
+		Context(ctx).
 		Project(r.project).
 		Resource("repositories").
-		Name(name).
+		Name(escapeRepository(name)).
 		Do().
 		Into(result)
 	return
 }
 
 func (r *Repository) List(query *options.RepositoriesListOptions) (result *[]model.Repository, err error) {
+	return r.ListContext(context.Background(), query)
+}
+
+// ListContext is like List but binds the request to ctx.
+func (r *Repository) ListContext(ctx context.Context, query *options.RepositoriesListOptions) (result *[]model.Repository, err error) {
 	result = &[]model.Repository{}
 	err = r.client.Get().
+		Context(ctx).
 		Project(r.project).
 		Resource("repositories").
 		Params(*query).
@@ -66,11 +99,56 @@ func (r *Repository) List(query *options.RepositoriesListOptions) (result *[]mod
 	return
 }
 
+// ListPager returns a Pager walking every repository of the project matching query.
+func (r *Repository) ListPager(ctx context.Context, query *options.RepositoriesListOptions) *rest2.Pager[model.Repository] {
+	return rest2.NewPager[model.Repository](ctx, func() *rest2.Request {
+		return r.client.Get().
+			Project(r.project).
+			Resource("repositories").
+			Params(*query)
+	})
+}
+
+// ListAll returns every repository of the project matching query, walking all pages.
+func (r *Repository) ListAll(query *options.RepositoriesListOptions) (result *[]model.Repository, err error) {
+	return r.ListAllContext(context.Background(), query)
+}
+
+// ListAllContext is like ListAll but binds the requests to ctx.
+func (r *Repository) ListAllContext(ctx context.Context, query *options.RepositoriesListOptions) (result *[]model.Repository, err error) {
+	items, err := r.ListPager(ctx, query).All()
+	return &items, err
+}
+
 func (r *Repository) Delete(name string) (err error) {
+	return r.DeleteContext(context.Background(), name)
+}
+
+// DeleteContext is like Delete but binds the request to ctx.
+func (r *Repository) DeleteContext(ctx context.Context, name string) (err error) {
 	err = r.client.Delete().
+		Context(ctx).
+		Project(r.project).
+		Resource("repositories").
+		Name(escapeRepository(name)).
+		Do().
+		Error()
+	return
+}
+
+// Update changes the description of the repository name.
+func (r *Repository) Update(name, description string) (err error) {
+	return r.UpdateContext(context.Background(), name, description)
+}
+
+// UpdateContext is like Update but binds the request to ctx.
+func (r *Repository) UpdateContext(ctx context.Context, name, description string) (err error) {
+	err = r.client.Put().
+		Context(ctx).
 		Project(r.project).
 		Resource("repositories").
-		Name(name).
+		Name(escapeRepository(name)).
+		Body(map[string]string{"description": description}).
 		Do().
 		Error()
 	return
@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package project
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+)
+
+func TestEscapeRepository(t *testing.T) {
+	for name, want := range map[string]string{
+		"nginx":        "nginx",
+		"a/b":          "a%2Fb",
+		"team/app/web": "team%2Fapp%2Fweb",
+	} {
+		if got := escapeRepository(name); got != want {
+			t.Errorf("escapeRepository(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestRepositoryRequests(t *testing.T) {
+	client, server := newTestClient(t)
+	repositories := client.Repositories("library")
+	path := "/projects/library/repositories/a%252Fb"
+
+	server.Reply(http.MethodGet, path, http.StatusOK, `{"id":4,"name":"library/a/b","artifact_count":2}`)
+	repository, err := repositories.Get("a/b")
+	if err != nil || repository.Name != "library/a/b" {
+		t.Errorf("unexpected repository %+v: %v", repository, err)
+	}
+
+	server.Reply(http.MethodPut, path, http.StatusOK, "")
+	if err := repositories.Update("a/b", "front end"); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"description":"front end"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+
+	server.Reply(http.MethodDelete, path, http.StatusOK, "")
+	if err := repositories.Delete("a/b"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSearchRepositories(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodGet, "/repositories", http.StatusOK, `[{"name":"library/nginx","pull_count":42},{"name":"team/nginx","pull_count":7}]`)
+
+	repositories, err := client.SearchRepositories(&model.Query{Q: "name=~nginx", Sort: "-pull_count"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*repositories) != 2 || (*repositories)[0].Name != "library/nginx" {
+		t.Errorf("unexpected repositories %+v", *repositories)
+	}
+	query := server.Last().Query
+	if query.Get("q") != "name=~nginx" || query.Get("sort") != "-pull_count" {
+		t.Errorf("unexpected query %v", query)
+	}
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package registry
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
+)
+
+// RegistriesInterface manages the registry endpoints replications pull from and push to.
+type RegistriesInterface interface {
+	Get(id int64) (result *model.Registry, err error)
+	List(query *model.Query) (results *[]model.Registry, err error)
+	ListPager(ctx context.Context, query *model.Query) *rest2.Pager[model.Registry]
+	ListAll(query *model.Query) (results *[]model.Registry, err error)
+	Create(registry *model.Registry) (id int64, err error)
+	Update(id int64, registry *model.RegistryUpdate) (err error)
+	Delete(id int64) (err error)
+	Ping(ping *model.RegistryPing) (err error)
+	PingByID(id int64) (err error)
+	Info(id int64) (result *model.RegistryInfo, err error)
+	Adapters() (results []string, err error)
+	AdapterInfo() (results map[string]*model.AdapterInfo, err error)
+	GetContext(ctx context.Context, id int64) (result *model.Registry, err error)
+	ListContext(ctx context.Context, query *model.Query) (results *[]model.Registry, err error)
+	ListAllContext(ctx context.Context, query *model.Query) (results *[]model.Registry, err error)
+	CreateContext(ctx context.Context, registry *model.Registry) (id int64, err error)
+	UpdateContext(ctx context.Context, id int64, registry *model.RegistryUpdate) (err error)
+	DeleteContext(ctx context.Context, id int64) (err error)
+	PingContext(ctx context.Context, ping *model.RegistryPing) (err error)
+	PingByIDContext(ctx context.Context, id int64) (err error)
+	InfoContext(ctx context.Context, id int64) (result *model.RegistryInfo, err error)
+	AdaptersContext(ctx context.Context) (results []string, err error)
+	AdapterInfoContext(ctx context.Context) (results map[string]*model.AdapterInfo, err error)
+}
+
+var _ RegistriesInterface = &RegistriesClient{}
+
+type RegistriesClient struct {
+	restClient rest2.Interface
+}
+
+func NewRegistriesClient(restClient *rest2.Config) (*RegistriesClient, error) {
+	client, err := rest2.RESTClientFor(restClient)
+	if err != nil {
+		return nil, err
+	}
+	return &RegistriesClient{restClient: client}, nil
+}
+
+func (r *RegistriesClient) Get(id int64) (result *model.Registry, err error) {
+	return r.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (r *RegistriesClient) GetContext(ctx context.Context, id int64) (result *model.Registry, err error) {
+	result = &model.Registry{}
+	err = r.restClient.Get().
+		Context(ctx).
+		Resource("registries").
+		Name(strconv.FormatInt(id, 10)).
+		Do().
+		Into(result)
+	return
+}
+
+// List lists one page of the registries, query.Q filters them, e.g. "name=~hub".
+func (r *RegistriesClient) List(query *model.Query) (results *[]model.Registry, err error) {
+	return r.ListContext(context.Background(), query)
+}
+
+// ListContext is like List but binds the request to ctx.
+func (r *RegistriesClient) ListContext(ctx context.Context, query *model.Query) (results *[]model.Registry, err error) {
+	results = &[]model.Registry{}
+	err = r.restClient.List().
+		Context(ctx).
+		Resource("registries").
+		Params(*query).
+		Do().
+		Into(results)
+	return
+}
+
+// ListPager returns a Pager walking every registry matching query.
+func (r *RegistriesClient) ListPager(ctx context.Context, query *model.Query) *rest2.Pager[model.Registry] {
+	return rest2.NewPager[model.Registry](ctx, func() *rest2.Request {
+		return r.restClient.List().
+			Resource("registries").
+			Params(*query)
+	})
+}
+
+// ListAll returns every registry matching query, walking all pages.
+func (r *RegistriesClient) ListAll(query *model.Query) (results *[]model.Registry, err error) {
+	return r.ListAllContext(context.Background(), query)
+}
+
+// ListAllContext is like ListAll but binds the requests to ctx.
+func (r *RegistriesClient) ListAllContext(ctx context.Context, query *model.Query) (results *[]model.Registry, err error) {
+	items, err := r.ListPager(ctx, query).All()
+	return &items, err
+}
+
+// Create creates a registry endpoint and returns its ID.
+func (r *RegistriesClient) Create(registry *model.Registry) (id int64, err error) {
+	return r.CreateContext(context.Background(), registry)
+}
+
+// CreateContext is like Create but binds the request to ctx.
+func (r *RegistriesClient) CreateContext(ctx context.Context, registry *model.Registry) (id int64, err error) {
+	return r.restClient.Post().
+		Context(ctx).
+		Resource("registries").
+		Body(registry).
+		Do().
+		CreatedID()
+}
+
+// Update changes the non-nil fields of registry on the registry id.
+func (r *RegistriesClient) Update(id int64, registry *model.RegistryUpdate) (err error) {
+	return r.UpdateContext(context.Background(), id, registry)
+}
+
+// UpdateContext is like Update but binds the request to ctx.
+func (r *RegistriesClient) UpdateContext(ctx context.Context, id int64, registry *model.RegistryUpdate) (err error) {
+	return r.restClient.Put().
+		Context(ctx).
+		Resource("registries").
+		Name(strconv.FormatInt(id, 10)).
+		Body(registry).
+		Do().
+		Error()
+}
+
+// Delete deletes the registry id, which must not be used by any replication policy.
+func (r *RegistriesClient) Delete(id int64) (err error) {
+	return r.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext is like Delete but binds the request to ctx.
+func (r *RegistriesClient) DeleteContext(ctx context.Context, id int64) (err error) {
+	return r.restClient.Delete().
+		Context(ctx).
+		Resource("registries").
+		Name(strconv.FormatInt(id, 10)).
+		Do().
+		Error()
+}
+
+// Ping checks that Harbor can reach and authenticate against a registry, stored or
+// not. A nil error means the registry is healthy.
+func (r *RegistriesClient) Ping(ping *model.RegistryPing) (err error) {
+	return r.PingContext(context.Background(), ping)
+}
+
+// PingContext is like Ping but binds the request to ctx.
+func (r *RegistriesClient) PingContext(ctx context.Context, ping *model.RegistryPing) (err error) {
+	return r.restClient.Post().
+		Context(ctx).
+		Resource("registries").
+		Name("ping").
+		Body(ping).
+		Do().
+		Error()
+}
+
+// PingByID checks that Harbor can reach the stored registry id with its stored settings.
+func (r *RegistriesClient) PingByID(id int64) (err error) {
+	return r.PingByIDContext(context.Background(), id)
+}
+
+// PingByIDContext is like PingByID but binds the request to ctx.
+func (r *RegistriesClient) PingByIDContext(ctx context.Context, id int64) (err error) {
+	return r.PingContext(ctx, &model.RegistryPing{ID: &id})
+}
+
+// Info returns the resource filters and triggers the registry id supports.
+func (r *RegistriesClient) Info(id int64) (result *model.RegistryInfo, err error) {
+	return r.InfoContext(context.Background(), id)
+}
+
+// InfoContext is like Info but binds the request to ctx.
+func (r *RegistriesClient) InfoContext(ctx context.Context, id int64) (result *model.RegistryInfo, err error) {
+	result = &model.RegistryInfo{}
+	err = r.restClient.Get().
+		Context(ctx).
+		Resource("registries").
+		Name(strconv.FormatInt(id, 10)).
+		Suffix("info").
+		Do().
+		Into(result)
+	return
+}
+
+// Adapters lists the registry types Harbor can replicate from and to.
+func (r *RegistriesClient) Adapters() (results []string, err error) {
+	return r.AdaptersContext(context.Background())
+}
+
+// AdaptersContext is like Adapters but binds the request to ctx.
+func (r *RegistriesClient) AdaptersContext(ctx context.Context) (results []string, err error) {
+	err = r.restClient.Get().
+		Context(ctx).
+		Resource("replication").
+		Name("adapters").
+		Do().
+		Into(&results)
+	return
+}
+
+// AdapterInfo returns how to configure a registry of each type, keyed by type.
+func (r *RegistriesClient) AdapterInfo() (results map[string]*model.AdapterInfo, err error) {
+	return r.AdapterInfoContext(context.Background())
+}
+
+// AdapterInfoContext is like AdapterInfo but binds the request to ctx.
+func (r *RegistriesClient) AdapterInfoContext(ctx context.Context) (results map[string]*model.AdapterInfo, err error) {
+	results = map[string]*model.AdapterInfo{}
+	err = r.restClient.Get().
+		Context(ctx).
+		Resource("replication").
+		Name("adapterinfos").
+		Do().
+		Into(&results)
+	return
+}
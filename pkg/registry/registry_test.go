@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package registry
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	"github.com/TimeBye/go-harbor/pkg/rest/resttest"
+)
+
+func newTestClient(t *testing.T) (*RegistriesClient, *resttest.Server) {
+	server := resttest.NewServer(t)
+	client, err := NewRegistriesClient(server.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server
+}
+
+func TestRegistryCredentials(t *testing.T) {
+	client, server := newTestClient(t)
+	server.ReplyCreated(http.MethodPost, "/registries", "2")
+	// Harbor masks the secret of stored registries.
+	server.Reply(http.MethodGet, "/registries/2", http.StatusOK,
+		`{"id":2,"name":"hub","type":"docker-hub","url":"https://hub.docker.com","credential":{"type":"basic","access_key":"bot","access_secret":"*****"},"insecure":false,"status":"healthy"}`)
+
+	id, err := client.Create(&model.Registry{
+		Name:       "hub",
+		Type:       model.RegistryTypeDockerHub,
+		URL:        "https://hub.docker.com",
+		Credential: &model.RegistryCredential{Type: model.CredentialTypeBasic, AccessKey: "bot", AccessSecret: "s3cr3t"},
+	})
+	if err != nil || id != 2 {
+		t.Fatalf("unexpected id %d: %v", id, err)
+	}
+	created := &model.Registry{}
+	server.Last().Decode(t, created)
+	if created.Credential == nil || created.Credential.AccessKey != "bot" || created.Credential.AccessSecret != "s3cr3t" {
+		t.Errorf("expected the credential to be sent on create, got %s", server.Last().Body)
+	}
+
+	registry, err := client.Get(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if registry.Credential == nil || registry.Credential.AccessKey != "bot" || registry.Credential.AccessSecret == "s3cr3t" {
+		t.Errorf("expected the secret not to be echoed back, got %+v", registry.Credential)
+	}
+}
+
+func TestRegistryUpdate(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodPut, "/registries/2", http.StatusOK, "")
+
+	secret, insecure := "n3w", false
+	if err := client.Update(2, &model.RegistryUpdate{AccessSecret: &secret, Insecure: &insecure}); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"access_secret":"n3w","insecure":false}` {
+		t.Errorf("unexpected body %s", body)
+	}
+}
+
+func TestRegistryPing(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodPost, "/registries/ping", http.StatusOK, "")
+
+	if err := client.PingByID(2); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"id":2}` {
+		t.Errorf("unexpected body %s", body)
+	}
+
+	kind, url := model.RegistryTypeHarbor, "https://harbor.example.com"
+	if err := client.Ping(&model.RegistryPing{Type: &kind, URL: &url}); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"type":"harbor","url":"https://harbor.example.com"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+
+	server.Reply(http.MethodPost, "/registries/ping", http.StatusBadRequest, `{"errors":[{"code":"BAD_REQUEST","message":"failed to ping endpoint"}]}`)
+	if err := client.PingByID(2); err == nil || !strings.Contains(err.Error(), "failed to ping endpoint") {
+		t.Errorf("expected the ping failure, got %v", err)
+	}
+}
+
+func TestRegistryAdapters(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodGet, "/replication/adapters", http.StatusOK, `["docker-hub","harbor"]`)
+	server.Reply(http.MethodGet, "/replication/adapterinfos", http.StatusOK,
+		`{"aws-ecr":{"endpoint_pattern":{"endpoint_type":"EndpointPatternTypeList","endpoints":[{"key":"us-east-1","value":"https://api.ecr.us-east-1.amazonaws.com"}]},"credential_pattern":null}}`)
+	server.Reply(http.MethodGet, "/registries/2/info", http.StatusOK, `{"type":"harbor","supported_triggers":["manual","scheduled","event_based"]}`)
+
+	adapters, err := client.Adapters()
+	if err != nil || len(adapters) != 2 {
+		t.Errorf("unexpected adapters %v: %v", adapters, err)
+	}
+	infos, err := client.AdapterInfo()
+	if err != nil || infos["aws-ecr"] == nil || len(infos["aws-ecr"].EndpointPattern.Endpoints) != 1 {
+		t.Errorf("unexpected adapter infos %+v: %v", infos, err)
+	}
+	info, err := client.Info(2)
+	if err != nil || len(info.SupportedTriggers) != 3 {
+		t.Errorf("unexpected info %+v: %v", info, err)
+	}
+}
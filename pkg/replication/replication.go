@@ -0,0 +1,279 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package replication
+
+import (
+	"context"
+	"io"
+	"strconv"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
+)
+
+// ReplicationInterface manages replication policies and follows their executions.
+type ReplicationInterface interface {
+	GetPolicy(id int64) (result *model.ReplicationPolicy, err error)
+	ListPolicies(query *PoliciesListOptions) (results *[]model.ReplicationPolicy, err error)
+	ListPoliciesPager(ctx context.Context, query *PoliciesListOptions) *rest2.Pager[model.ReplicationPolicy]
+	CreatePolicy(policy *model.ReplicationPolicy) (id int64, err error)
+	UpdatePolicy(id int64, policy *model.ReplicationPolicy) (err error)
+	DeletePolicy(id int64) (err error)
+	StartExecution(policyID int64) (id int64, err error)
+	StopExecution(id int64) (err error)
+	GetExecution(id int64) (result *model.ReplicationExecution, err error)
+	ListExecutions(query *ExecutionsListOptions) (results *[]model.ReplicationExecution, err error)
+	ListExecutionsPager(ctx context.Context, query *ExecutionsListOptions) *rest2.Pager[model.ReplicationExecution]
+	ListTasks(executionID int64, query *TasksListOptions) (results *[]model.ReplicationTask, err error)
+	ListTasksPager(ctx context.Context, executionID int64, query *TasksListOptions) *rest2.Pager[model.ReplicationTask]
+	TaskLog(executionID, taskID int64) (log io.ReadCloser, err error)
+	GetPolicyContext(ctx context.Context, id int64) (result *model.ReplicationPolicy, err error)
+	ListPoliciesContext(ctx context.Context, query *PoliciesListOptions) (results *[]model.ReplicationPolicy, err error)
+	CreatePolicyContext(ctx context.Context, policy *model.ReplicationPolicy) (id int64, err error)
+	UpdatePolicyContext(ctx context.Context, id int64, policy *model.ReplicationPolicy) (err error)
+	DeletePolicyContext(ctx context.Context, id int64) (err error)
+	StartExecutionContext(ctx context.Context, policyID int64) (id int64, err error)
+	StopExecutionContext(ctx context.Context, id int64) (err error)
+	GetExecutionContext(ctx context.Context, id int64) (result *model.ReplicationExecution, err error)
+	ListExecutionsContext(ctx context.Context, query *ExecutionsListOptions) (results *[]model.ReplicationExecution, err error)
+	ListTasksContext(ctx context.Context, executionID int64, query *TasksListOptions) (results *[]model.ReplicationTask, err error)
+	TaskLogContext(ctx context.Context, executionID, taskID int64) (log io.ReadCloser, err error)
+}
+
+var _ ReplicationInterface = &ReplicationClient{}
+
+type PoliciesListOptions struct {
+	*model.Query
+	// Name The replication policy name, fuzzy matched
+	Name string `json:"name,omitempty"`
+}
+
+type ExecutionsListOptions struct {
+	*model.Query
+	// PolicyID The ID of the policy that the executions belong to
+	PolicyID int64 `json:"policy_id,omitempty"`
+	// Status The execution status, one of the model.ExecutionStatus constants
+	Status string `json:"status,omitempty"`
+	// Trigger The trigger mode
+	Trigger string `json:"trigger,omitempty"`
+}
+
+type TasksListOptions struct {
+	*model.Query
+	// Status The task status, one of the model.ExecutionStatus constants
+	Status string `json:"status,omitempty"`
+	// ResourceType The type of the replicated resource
+	ResourceType string `json:"resource_type,omitempty"`
+}
+
+type ReplicationClient struct {
+	restClient rest2.Interface
+}
+
+func NewReplicationClient(restClient *rest2.Config) (*ReplicationClient, error) {
+	client, err := rest2.RESTClientFor(restClient)
+	if err != nil {
+		return nil, err
+	}
+	return &ReplicationClient{restClient: client}, nil
+}
+
+// policies points req at /replication/policies.
+func policies(req *rest2.Request) *rest2.Request {
+	return req.Resource("replication").Name("policies")
+}
+
+// executions points req at /replication/executions.
+func executions(req *rest2.Request) *rest2.Request {
+	return req.Resource("replication").Name("executions")
+}
+
+func (r *ReplicationClient) GetPolicy(id int64) (result *model.ReplicationPolicy, err error) {
+	return r.GetPolicyContext(context.Background(), id)
+}
+
+// GetPolicyContext is like GetPolicy but binds the request to ctx.
+func (r *ReplicationClient) GetPolicyContext(ctx context.Context, id int64) (result *model.ReplicationPolicy, err error) {
+	result = &model.ReplicationPolicy{}
+	err = policies(r.restClient.Get().Context(ctx)).
+		Suffix(strconv.FormatInt(id, 10)).
+		Do().
+		Into(result)
+	return
+}
+
+func (r *ReplicationClient) ListPolicies(query *PoliciesListOptions) (results *[]model.ReplicationPolicy, err error) {
+	return r.ListPoliciesContext(context.Background(), query)
+}
+
+// ListPoliciesContext is like ListPolicies but binds the request to ctx.
+func (r *ReplicationClient) ListPoliciesContext(ctx context.Context, query *PoliciesListOptions) (results *[]model.ReplicationPolicy, err error) {
+	results = &[]model.ReplicationPolicy{}
+	err = policies(r.restClient.List().Context(ctx)).
+		Params(*query).
+		Do().
+		Into(results)
+	return
+}
+
+// ListPoliciesPager returns a Pager walking every replication policy matching query.
+func (r *ReplicationClient) ListPoliciesPager(ctx context.Context, query *PoliciesListOptions) *rest2.Pager[model.ReplicationPolicy] {
+	return rest2.NewPager[model.ReplicationPolicy](ctx, func() *rest2.Request {
+		return policies(r.restClient.List()).
+			Params(*query)
+	})
+}
+
+// CreatePolicy creates a replication policy and returns its ID.
+func (r *ReplicationClient) CreatePolicy(policy *model.ReplicationPolicy) (id int64, err error) {
+	return r.CreatePolicyContext(context.Background(), policy)
+}
+
+// CreatePolicyContext is like CreatePolicy but binds the request to ctx.
+func (r *ReplicationClient) CreatePolicyContext(ctx context.Context, policy *model.ReplicationPolicy) (id int64, err error) {
+	return policies(r.restClient.Post().Context(ctx)).
+		Body(policy).
+		Do().
+		CreatedID()
+}
+
+// UpdatePolicy replaces the replication policy id with policy.
+func (r *ReplicationClient) UpdatePolicy(id int64, policy *model.ReplicationPolicy) (err error) {
+	return r.UpdatePolicyContext(context.Background(), id, policy)
+}
+
+// UpdatePolicyContext is like UpdatePolicy but binds the request to ctx.
+func (r *ReplicationClient) UpdatePolicyContext(ctx context.Context, id int64, policy *model.ReplicationPolicy) (err error) {
+	return policies(r.restClient.Put().Context(ctx)).
+		Suffix(strconv.FormatInt(id, 10)).
+		Body(policy).
+		Do().
+		Error()
+}
+
+// DeletePolicy deletes the replication policy id, which must have no running execution.
+func (r *ReplicationClient) DeletePolicy(id int64) (err error) {
+	return r.DeletePolicyContext(context.Background(), id)
+}
+
+// DeletePolicyContext is like DeletePolicy but binds the request to ctx.
+func (r *ReplicationClient) DeletePolicyContext(ctx context.Context, id int64) (err error) {
+	return policies(r.restClient.Delete().Context(ctx)).
+		Suffix(strconv.FormatInt(id, 10)).
+		Do().
+		Error()
+}
+
+// StartExecution runs the replication policy policyID and returns the ID of the execution.
+func (r *ReplicationClient) StartExecution(policyID int64) (id int64, err error) {
+	return r.StartExecutionContext(context.Background(), policyID)
+}
+
+// StartExecutionContext is like StartExecution but binds the request to ctx.
+func (r *ReplicationClient) StartExecutionContext(ctx context.Context, policyID int64) (id int64, err error) {
+	return executions(r.restClient.Post().Context(ctx)).
+		Body(map[string]int64{"policy_id": policyID}).
+		Do().
+		CreatedID()
+}
+
+// StopExecution stops the execution id, its running tasks are stopped too.
+func (r *ReplicationClient) StopExecution(id int64) (err error) {
+	return r.StopExecutionContext(context.Background(), id)
+}
+
+// StopExecutionContext is like StopExecution but binds the request to ctx.
+func (r *ReplicationClient) StopExecutionContext(ctx context.Context, id int64) (err error) {
+	return executions(r.restClient.Put().Context(ctx)).
+		Suffix(strconv.FormatInt(id, 10)).
+		Do().
+		Error()
+}
+
+func (r *ReplicationClient) GetExecution(id int64) (result *model.ReplicationExecution, err error) {
+	return r.GetExecutionContext(context.Background(), id)
+}
+
+// GetExecutionContext is like GetExecution but binds the request to ctx.
+func (r *ReplicationClient) GetExecutionContext(ctx context.Context, id int64) (result *model.ReplicationExecution, err error) {
+	result = &model.ReplicationExecution{}
+	err = executions(r.restClient.Get().Context(ctx)).
+		Suffix(strconv.FormatInt(id, 10)).
+		Do().
+		Into(result)
+	return
+}
+
+// ListExecutions lists the executions matching query, e.g. those of a policy.
+func (r *ReplicationClient) ListExecutions(query *ExecutionsListOptions) (results *[]model.ReplicationExecution, err error) {
+	return r.ListExecutionsContext(context.Background(), query)
+}
+
+// ListExecutionsContext is like ListExecutions but binds the request to ctx.
+func (r *ReplicationClient) ListExecutionsContext(ctx context.Context, query *ExecutionsListOptions) (results *[]model.ReplicationExecution, err error) {
+	results = &[]model.ReplicationExecution{}
+	err = executions(r.restClient.List().Context(ctx)).
+		Params(*query).
+		Do().
+		Into(results)
+	return
+}
+
+// ListExecutionsPager returns a Pager walking every execution matching query.
+func (r *ReplicationClient) ListExecutionsPager(ctx context.Context, query *ExecutionsListOptions) *rest2.Pager[model.ReplicationExecution] {
+	return rest2.NewPager[model.ReplicationExecution](ctx, func() *rest2.Request {
+		return executions(r.restClient.List()).
+			Params(*query)
+	})
+}
+
+// ListTasks lists the tasks of the execution executionID matching query.
+func (r *ReplicationClient) ListTasks(executionID int64, query *TasksListOptions) (results *[]model.ReplicationTask, err error) {
+	return r.ListTasksContext(context.Background(), executionID, query)
+}
+
+// ListTasksContext is like ListTasks but binds the request to ctx.
+func (r *ReplicationClient) ListTasksContext(ctx context.Context, executionID int64, query *TasksListOptions) (results *[]model.ReplicationTask, err error) {
+	results = &[]model.ReplicationTask{}
+	err = executions(r.restClient.List().Context(ctx)).
+		Suffix(strconv.FormatInt(executionID, 10), "tasks").
+		Params(*query).
+		Do().
+		Into(results)
+	return
+}
+
+// ListTasksPager returns a Pager walking every task of the execution executionID matching query.
+func (r *ReplicationClient) ListTasksPager(ctx context.Context, executionID int64, query *TasksListOptions) *rest2.Pager[model.ReplicationTask] {
+	return rest2.NewPager[model.ReplicationTask](ctx, func() *rest2.Request {
+		return executions(r.restClient.List()).
+			Suffix(strconv.FormatInt(executionID, 10), "tasks").
+			Params(*query)
+	})
+}
+
+// TaskLog streams the log of the task taskID of the execution executionID. The
+// caller must close the returned log.
+func (r *ReplicationClient) TaskLog(executionID, taskID int64) (log io.ReadCloser, err error) {
+	return r.TaskLogContext(context.Background(), executionID, taskID)
+}
+
+// TaskLogContext is like TaskLog but binds the request to ctx.
+func (r *ReplicationClient) TaskLogContext(ctx context.Context, executionID, taskID int64) (log io.ReadCloser, err error) {
+	return executions(r.restClient.Get().Context(ctx)).
+		Suffix(strconv.FormatInt(executionID, 10), "tasks", strconv.FormatInt(taskID, 10), "log").
+		Stream()
+}
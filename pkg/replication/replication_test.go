@@ -0,0 +1,176 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package replication
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	"github.com/TimeBye/go-harbor/pkg/rest/resttest"
+)
+
+func newTestClient(t *testing.T) (*ReplicationClient, *resttest.Server) {
+	server := resttest.NewServer(t)
+	client, err := NewReplicationClient(server.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server
+}
+
+func TestReplicationCreatePolicy(t *testing.T) {
+	client, server := newTestClient(t)
+	server.ReplyCreated(http.MethodPost, "/replication/policies", "6")
+
+	for _, c := range []struct {
+		trigger *model.ReplicationTrigger
+		filters []*model.ReplicationFilter
+		want    struct{ trigger, filters string }
+	}{
+		{
+			trigger: model.ManualTrigger(),
+			filters: []*model.ReplicationFilter{model.NameFilter("library/**")},
+			want: struct{ trigger, filters string }{
+				`{"type":"manual"}`,
+				`[{"type":"name","value":"library/**"}]`,
+			},
+		},
+		{
+			trigger: model.ScheduledTrigger("0 0 2 * * *"),
+			filters: []*model.ReplicationFilter{model.TagFilter("v*", false), model.LabelFilter([]string{"qa"}, true)},
+			want: struct{ trigger, filters string }{
+				`{"type":"scheduled","trigger_settings":{"cron":"0 0 2 * * *"}}`,
+				`[{"type":"tag","value":"v*","decoration":"matches"},{"type":"label","value":["qa"],"decoration":"excludes"}]`,
+			},
+		},
+		{
+			trigger: model.EventBasedTrigger(),
+			filters: []*model.ReplicationFilter{model.ResourceFilter(model.ResourceTypeImage)},
+			want: struct{ trigger, filters string }{
+				`{"type":"event_based"}`,
+				`[{"type":"resource","value":"image"}]`,
+			},
+		},
+	} {
+		id, err := client.CreatePolicy(&model.ReplicationPolicy{
+			Name:         "push-" + c.trigger.Type,
+			DestRegistry: &model.Registry{ID: 2},
+			Trigger:      c.trigger,
+			Filters:      c.filters,
+			Enabled:      true,
+		})
+		if err != nil || id != 6 {
+			t.Fatalf("%s: unexpected id %d: %v", c.trigger.Type, id, err)
+		}
+		body := struct {
+			Trigger json.RawMessage `json:"trigger"`
+			Filters json.RawMessage `json:"filters"`
+		}{}
+		server.Last().Decode(t, &body)
+		if string(body.Trigger) != c.want.trigger {
+			t.Errorf("%s: unexpected trigger %s", c.trigger.Type, body.Trigger)
+		}
+		if string(body.Filters) != c.want.filters {
+			t.Errorf("%s: unexpected filters %s", c.trigger.Type, body.Filters)
+		}
+	}
+}
+
+func TestReplicationExecutions(t *testing.T) {
+	client, server := newTestClient(t)
+	server.ReplyCreated(http.MethodPost, "/replication/executions", "31")
+	server.Reply(http.MethodPut, "/replication/executions/31", http.StatusOK, "")
+
+	id, err := client.StartExecution(6)
+	if err != nil || id != 31 {
+		t.Fatalf("unexpected id %d: %v", id, err)
+	}
+	if body := string(server.Last().Body); body != `{"policy_id":6}` {
+		t.Errorf("unexpected body %s", body)
+	}
+
+	if err := client.StopExecution(31); err != nil {
+		t.Fatal(err)
+	}
+	if last := server.Last(); last.Method != http.MethodPut || last.Path != "/api/v2.0/replication/executions/31" {
+		t.Errorf("unexpected request %s %s", last.Method, last.Path)
+	}
+}
+
+func TestReplicationListTasksPager(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Handle(http.MethodGet, "/replication/executions/31/tasks", func(w http.ResponseWriter, req *http.Request) {
+		page := req.URL.Query().Get("page")
+		if page == "1" {
+			w.Header().Set("Link", `</api/v2.0/replication/executions/31/tasks?page=2&page_size=1&status=Failed>; rel="next"`)
+		}
+		fmt.Fprintf(w, `[{"id":%s,"execution_id":31,"status":"Failed"}]`, page)
+	})
+
+	tasks, err := client.ListTasksPager(context.Background(), 31, &TasksListOptions{Query: &model.Query{PageSize: 1}, Status: model.ExecutionStatusFailed}).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 2 || tasks[0].ID != 1 || tasks[1].ID != 2 {
+		t.Errorf("unexpected tasks %+v", tasks)
+	}
+	requests := server.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 pages, got %d requests", len(requests))
+	}
+	if query := requests[0].Query; query.Get("status") != model.ExecutionStatusFailed || query.Get("page_size") != "1" {
+		t.Errorf("unexpected query %v", query)
+	}
+}
+
+func TestReplicationTaskLog(t *testing.T) {
+	client, server := newTestClient(t)
+	closed := make(chan struct{})
+	server.Handle(http.MethodGet, "/replication/executions/31/tasks/4/log", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprintln(w, "2024-04-08T02:00:00Z [INFO] copying library/nginx:1.25")
+		w.(http.Flusher).Flush()
+		// The rest of the log never comes, only closing the stream ends the request.
+		select {
+		case <-req.Context().Done():
+			close(closed)
+		case <-time.After(5 * time.Second):
+		}
+	})
+
+	log, err := client.TaskLog(31, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	line, err := bufio.NewReader(log).ReadString('\n')
+	if err != nil || line != "2024-04-08T02:00:00Z [INFO] copying library/nginx:1.25\n" {
+		t.Errorf("unexpected line %q: %v", line, err)
+	}
+	if err := log.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Error("expected closing the log to end the request")
+	}
+}
@@ -29,6 +29,8 @@ type Interface interface {
 	Put() *Request
 	List() *Request
 	Get() *Request
+	Head() *Request
+	Patch() *Request
 	Delete() *Request
 }
 
@@ -109,6 +111,16 @@ func (c *RESTClient) Get() *Request {
 	return c.Verb("GET")
 }
 
+// Head begins a HEAD request. Short for c.Verb("HEAD").
+func (c *RESTClient) Head() *Request {
+	return c.Verb("HEAD")
+}
+
+// Patch begins a PATCH request. Short for c.Verb("PATCH").
+func (c *RESTClient) Patch() *Request {
+	return c.Verb("PATCH")
+}
+
 // Delete begins a DELETE request. Short for c.Verb("DELETE").
 func (c *RESTClient) Delete() *Request {
 	return c.Verb("DELETE")
@@ -131,5 +143,7 @@ func (c *RESTClient) Verb(verb string) *Request {
 	if c.Client == nil {
 		return NewRequest(nil, verb, c.base, c.headers, c.versionedAPIPath, c.contentConfig, c.Throttle, 0)
 	}
-	return NewRequest(c.Client, verb, c.base, c.headers, c.versionedAPIPath, c.contentConfig, c.Throttle, c.Client.Timeout)
+	// The overall timeout is enforced by c.Client itself, there is no need to
+	// forward it as a "timeout" parameter to Harbor.
+	return NewRequest(c.Client, verb, c.base, c.headers, c.versionedAPIPath, c.contentConfig, c.Throttle, 0)
 }
@@ -17,11 +17,11 @@ package rest
 
 import (
 	"context"
-	"encoding/base64"
-	"fmt"
 	flowcontrol2 "github.com/TimeBye/go-harbor/pkg/rest/util/flowcontrol"
 	"net"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -62,10 +62,13 @@ type TLSClientConfig struct {
 const (
 	DefaultQPS            float32 = 5.0
 	DefaultBurst          int     = 10
-	DefaultTimeOut        int     = 10
 	DefaultVersionApiPath string  = "/api/v2.0"
 )
 
+// Deprecated: DefaultTimeOut is not applied to any request. A zero Config.Timeout
+// means no timeout, bound the requests with Request.Context or Request.Timeout.
+const DefaultTimeOut int = 10
+
 // Config holds the common attributes that can be passed to a Kubernetes client on
 // initialization.
 type Config struct {
@@ -74,7 +77,7 @@ type Config struct {
 	// be appended to all request URIs used to access the apiserver. This allows a frontend
 	// proxy to easily relocate all of the apiserver endpoints.
 	Host string
-	// APIPath is a sub-path that points to an API root.
+	// APIPath is a sub-path that points to an API root. If empty, DefaultVersionApiPath is used.
 	APIPath string
 
 	// ContentConfig contains settings that affect how objects are transformed when
@@ -95,6 +98,16 @@ type Config struct {
 	// The last successfully read value takes precedence over BearerToken.
 	BearerTokenFile string
 
+	// Server requires authentication as a Harbor robot account. RobotName may be
+	// given with or without its "robot$" prefix.
+	RobotName   string
+	RobotSecret string
+
+	// Path to a file containing the RobotSecret, such as a mounted Kubernetes secret.
+	// If set, the contents are periodically read.
+	// The last successfully read value takes precedence over RobotSecret.
+	RobotSecretFile string
+
 	/*	// Impersonate is the configuration that RESTClient will use for impersonation.
 		Impersonate ImpersonationConfig
 
@@ -128,7 +141,7 @@ type Config struct {
 	//
 	// A future release will change this field to an array. Use config.Wrap()
 	// instead of setting this value directly.
-	//	WrapTransport transport.WrapperFunc
+	WrapTransport WrapperFunc
 
 	// QPS indicates the maximum QPS to the master from this client.
 	// If it's zero, the created RESTClient will use DefaultQPS: 5
@@ -141,12 +154,18 @@ type Config struct {
 	// Rate limiter for limiting connections to the master from this client. If present overwrites QPS/Burst
 	RateLimiter flowcontrol2.RateLimiter
 
-	// The maximum length of time to wait before giving up on a server request. A value of zero means no timeout.
+	// The maximum length of time to wait before giving up on a server request,
+	// including reading the response body. A value of zero means no timeout, bound
+	// single requests with Request.Context or Request.Timeout instead.
 	Timeout time.Duration
 
 	// Dial specifies the dial function for creating unencrypted TCP connections.
 	Dial func(ctx context.Context, network, address string) (net.Conn, error)
 
+	// Proxy is the proxy func to be used for all requests made by this
+	// transport. If Proxy is nil, http.ProxyFromEnvironment is used.
+	Proxy func(*http.Request) (*url.URL, error)
+
 	// Version forces a specific version to be used (if registered)
 	// Do we need this?
 	// Version string
@@ -165,36 +184,54 @@ func RESTClientFor(config *Config) (*RESTClient, error) {
 	if config.Burst == 0 {
 		burst = DefaultBurst
 	}
-
-	if config.Timeout == 0 {
-		burst = DefaultTimeOut
+	host, apiPath := config.Host, config.APIPath
+	if len(host) == 0 && len(apiPath) > 0 && !strings.HasPrefix(apiPath, "/") {
+		// Configs built before Host was honored carried the server in APIPath.
+		host, apiPath = apiPath, ""
 	}
-
-	baseURL, err := DefaultServerURL(config.APIPath)
+	if len(apiPath) == 0 {
+		apiPath = DefaultVersionApiPath
+	}
+	baseURL, err := DefaultServerURL(host)
 	if err != nil {
 		return nil, err
 	}
-	transport := TransportFor()
-
-	var httpClient *http.Client
-	if transport != http.DefaultTransport {
-		httpClient = &http.Client{Transport: transport}
-		if config.Timeout > 0 {
-			httpClient.Timeout = config.Timeout
-		}
+	transport, err := TransportFor(config)
+	if err != nil {
+		return nil, err
 	}
-	headers := map[string]string{}
-	if config.Username != "" && config.Password != "" {
-		pwd := fmt.Sprintf("%s:%s", config.Username, config.Password)
-		headers["authorization"] = fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(pwd)))
+	httpClient := &http.Client{
+		Transport: transport,
+		Timeout:   config.Timeout,
 	}
-	return NewRESTClient(baseURL, DefaultVersionApiPath, config.ContentConfig, headers, qps, burst, config.RateLimiter, httpClient)
+	return NewRESTClient(baseURL, apiPath, config.ContentConfig, nil, qps, burst, config.RateLimiter, httpClient)
+}
+
+// Wrap adds a transport middleware function that will give the caller
+// an opportunity to wrap the underlying http.RoundTripper prior to the
+// first API call being made. The provided function is invoked after any
+// existing transport wrappers are invoked.
+func (c *Config) Wrap(fn WrapperFunc) {
+	c.WrapTransport = Wrappers(c.WrapTransport, fn)
 }
 
 func NewDefaultConfig(host string, username string, password string) *Config {
 	return &Config{
-		APIPath:  host,
+		Host:     host,
+		APIPath:  DefaultVersionApiPath,
 		Username: username,
 		Password: password,
 	}
 }
+
+// NewRobotConfig returns a Config authenticating as the robot account name. If
+// secretFile is not empty the secret is read from it and reloaded periodically.
+func NewRobotConfig(host string, name string, secret string, secretFile string) *Config {
+	return &Config{
+		Host:            host,
+		APIPath:         DefaultVersionApiPath,
+		RobotName:       name,
+		RobotSecret:     secret,
+		RobotSecretFile: secretFile,
+	}
+}
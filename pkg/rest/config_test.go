@@ -15,7 +15,10 @@ See the License for the specific language governing permissions and
 
 package rest
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestRESTClientFor(t *testing.T) {
 	config := NewDefaultConfig("xx", "", "")
@@ -25,3 +28,23 @@ func TestRESTClientFor(t *testing.T) {
 	}
 
 }
+
+func TestRESTClientForTimeout(t *testing.T) {
+	client, err := RESTClientFor(NewDefaultConfig("harbor.example.com", "", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.Client.Timeout != 0 {
+		t.Errorf("expected a zero Timeout to mean no timeout, got %v", client.Client.Timeout)
+	}
+
+	config := NewDefaultConfig("harbor.example.com", "", "")
+	config.Timeout = 30 * time.Second
+	client, err = RESTClientFor(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.Client.Timeout != 30*time.Second {
+		t.Errorf("expected the configured timeout, got %v", client.Client.Timeout)
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Error codes Harbor puts into the "code" field of its error envelope.
+const (
+	ErrCodeBadRequest         = "BAD_REQUEST"
+	ErrCodeUnauthorized       = "UNAUTHORIZED"
+	ErrCodeForbidden          = "FORBIDDEN"
+	ErrCodeNotFound           = "NOT_FOUND"
+	ErrCodeConflict           = "CONFLICT"
+	ErrCodePreconditionFailed = "PRECONDITION"
+	ErrCodeTooManyRequests    = "TOO_MANY_REQUEST"
+	ErrCodeInternalError      = "UNKNOWN"
+)
+
+// maxErrorBodySize caps how much of an unrecognized error body is kept in a StatusError.
+const maxErrorBodySize = 1024
+
+// ErrorDetail is a single entry of Harbor's {"errors":[{"code","message"}]} envelope.
+type ErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// errorEnvelope is the body Harbor sends along with a non 2xx status code.
+type errorEnvelope struct {
+	Errors []ErrorDetail `json:"errors"`
+}
+
+// StatusError is returned when the server answers with a status code outside of 2xx.
+// Errors holds the parsed Harbor error envelope; if the body could not be parsed
+// it is kept verbatim in Body instead.
+type StatusError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Errors are the code/message pairs reported by Harbor
+	Errors []ErrorDetail
+	// RequestID is the X-Request-Id the server associated with the request
+	RequestID string
+	// Method and URL identify the request that failed
+	Method string
+	URL    string
+	// Body is the raw response body when it is not a Harbor error envelope
+	Body string
+}
+
+// Error returns a textual description of 'e'.
+func (e *StatusError) Error() string {
+	msg := fmt.Sprintf("%s url:%s StatusCode: %d", e.Method, e.URL, e.StatusCode)
+	if len(e.Errors) > 0 {
+		details := make([]string, 0, len(e.Errors))
+		for _, d := range e.Errors {
+			details = append(details, fmt.Sprintf("%s: %s", d.Code, d.Message))
+		}
+		msg = fmt.Sprintf("%s message:%s", msg, strings.Join(details, "; "))
+	} else if len(e.Body) > 0 {
+		msg = fmt.Sprintf("%s message:%s", msg, e.Body)
+	}
+	if len(e.RequestID) > 0 {
+		msg = fmt.Sprintf("%s request_id:%s", msg, e.RequestID)
+	}
+	return msg
+}
+
+// HasCode reports whether Harbor returned the given error code.
+func (e *StatusError) HasCode(code string) bool {
+	for _, d := range e.Errors {
+		if d.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// newStatusError builds a StatusError out of a failed response and its already read body.
+func newStatusError(body []byte, resp *http.Response, req *http.Request) *StatusError {
+	e := &StatusError{
+		StatusCode: resp.StatusCode,
+		Method:     req.Method,
+		URL:        req.URL.Path,
+	}
+	if resp.Header != nil {
+		e.RequestID = resp.Header.Get("X-Request-Id")
+	}
+	if len(e.RequestID) == 0 {
+		e.RequestID = req.Header.Get("X-Request-Id")
+	}
+	envelope := errorEnvelope{}
+	if err := json.Unmarshal(body, &envelope); err == nil && len(envelope.Errors) > 0 {
+		e.Errors = envelope.Errors
+	} else {
+		e.Body = strings.TrimSpace(string(body))
+		if len(e.Body) > maxErrorBodySize {
+			e.Body = e.Body[:maxErrorBodySize] + fmt.Sprintf(" [truncated %d chars]", len(e.Body)-maxErrorBodySize)
+		}
+	}
+	return e
+}
+
+// StatusCode returns the HTTP status code carried by err, or 0 if err is not a *StatusError.
+func StatusCode(err error) int {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode
+	}
+	return 0
+}
+
+func hasStatusOrCode(err error, status int, code string) bool {
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	return statusErr.StatusCode == status || statusErr.HasCode(code)
+}
+
+// IsBadRequest returns true if err indicates the request was rejected as invalid.
+func IsBadRequest(err error) bool {
+	return hasStatusOrCode(err, http.StatusBadRequest, ErrCodeBadRequest)
+}
+
+// IsUnauthorized returns true if err indicates the credentials are missing or wrong.
+func IsUnauthorized(err error) bool {
+	return hasStatusOrCode(err, http.StatusUnauthorized, ErrCodeUnauthorized)
+}
+
+// IsForbidden returns true if err indicates the caller is not allowed to perform the operation.
+func IsForbidden(err error) bool {
+	return hasStatusOrCode(err, http.StatusForbidden, ErrCodeForbidden)
+}
+
+// IsNotFound returns true if err indicates the resource does not exist.
+func IsNotFound(err error) bool {
+	return hasStatusOrCode(err, http.StatusNotFound, ErrCodeNotFound)
+}
+
+// IsConflict returns true if err indicates the resource already exists or is in conflict.
+func IsConflict(err error) bool {
+	return hasStatusOrCode(err, http.StatusConflict, ErrCodeConflict)
+}
+
+// IsPreconditionFailed returns true if err indicates a precondition of the operation was not met.
+func IsPreconditionFailed(err error) bool {
+	return hasStatusOrCode(err, http.StatusPreconditionFailed, ErrCodePreconditionFailed)
+}
+
+// IsTooManyRequests returns true if err indicates the server is throttling the client.
+func IsTooManyRequests(err error) bool {
+	return hasStatusOrCode(err, http.StatusTooManyRequests, ErrCodeTooManyRequests)
+}
+
+// IsInternalError returns true if err indicates the server failed to process the request.
+func IsInternalError(err error) bool {
+	return hasStatusOrCode(err, http.StatusInternalServerError, ErrCodeInternalError)
+}
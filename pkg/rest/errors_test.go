@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func doAgainst(t *testing.T, handler http.HandlerFunc) error {
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("parse server url: %v", err)
+	}
+	return NewRequest(server.Client(), "GET", u, nil, DefaultVersionApiPath, ContentConfig{}, nil, 0).
+		Resource("projects").
+		Name("demo").
+		Do().
+		Into(&struct{}{})
+}
+
+func TestStatusErrorFromEnvelope(t *testing.T) {
+	err := doAgainst(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "abc")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors":[{"code":"NOT_FOUND","message":"project demo not found"}]}`)
+	})
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected a *StatusError, got %T: %v", err, err)
+	}
+	if statusErr.StatusCode != http.StatusNotFound || statusErr.RequestID != "abc" || statusErr.URL != "/api/v2.0/projects/demo" {
+		t.Errorf("unexpected status error: %#v", statusErr)
+	}
+	if len(statusErr.Errors) != 1 || statusErr.Errors[0].Message != "project demo not found" {
+		t.Errorf("unexpected error details: %#v", statusErr.Errors)
+	}
+	if !IsNotFound(err) || IsConflict(err) || StatusCode(err) != http.StatusNotFound {
+		t.Errorf("unexpected classification for %v", err)
+	}
+}
+
+func TestStatusErrorKeepsUnstructuredBody(t *testing.T) {
+	err := doAgainst(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "upstream unavailable\n")
+	})
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected a *StatusError, got %T: %v", err, err)
+	}
+	if statusErr.Body != "upstream unavailable" || len(statusErr.Errors) != 0 {
+		t.Errorf("unexpected status error: %#v", statusErr)
+	}
+}
+
+func TestStatusErrorHelpers(t *testing.T) {
+	cases := []struct {
+		err   error
+		check func(error) bool
+	}{
+		{&StatusError{StatusCode: http.StatusBadRequest}, IsBadRequest},
+		{&StatusError{StatusCode: http.StatusUnauthorized}, IsUnauthorized},
+		{&StatusError{StatusCode: http.StatusForbidden}, IsForbidden},
+		{&StatusError{StatusCode: http.StatusConflict}, IsConflict},
+		{&StatusError{StatusCode: http.StatusPreconditionFailed}, IsPreconditionFailed},
+		{&StatusError{StatusCode: http.StatusTooManyRequests}, IsTooManyRequests},
+		{&StatusError{StatusCode: http.StatusInternalServerError}, IsInternalError},
+		{&StatusError{StatusCode: http.StatusOK, Errors: []ErrorDetail{{Code: ErrCodeConflict}}}, IsConflict},
+		{fmt.Errorf("wrapped: %w", &StatusError{StatusCode: http.StatusNotFound}), IsNotFound},
+	}
+	for i, c := range cases {
+		if !c.check(c.err) {
+			t.Errorf("case %d: expected %v to match", i, c.err)
+		}
+	}
+	if IsNotFound(errors.New("not found")) {
+		t.Error("plain errors must not be classified")
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package rest
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// DefaultPageSize is the page size a Pager asks for when the request does not carry one.
+// It is the largest page size Harbor accepts.
+const DefaultPageSize int64 = 100
+
+// Pager walks every page of a Harbor list endpoint and hands out the decoded
+// items one at a time.
+//
+// Example usage:
+//
+//	pager := NewPager[models.Project](ctx, func() *Request {
+//		return client.Get().Resource("projects")
+//	})
+//	for pager.Next() {
+//		project := pager.Item()
+//		...
+//	}
+//	if err := pager.Err(); err != nil { ... }
+//
+// Pages are followed through the Link rel="next" header. When the server reports
+// X-Total-Count and WithPrefetch is used, the remaining pages are requested by
+// number, several of them in parallel, and still returned in order.
+type Pager[T any] struct {
+	ctx        context.Context
+	newRequest func() *Request
+	pageSize   int64
+	prefetch   int
+
+	items []T
+	index int
+	err   error
+	done  bool
+
+	page     int64
+	lastPage int64
+	total    int64
+	hasTotal bool
+	next     string
+	pending  []chan pageResult[T]
+}
+
+type pageResult[T any] struct {
+	items []T
+	err   error
+}
+
+// NewPager returns a Pager issuing the requests built by newRequest. newRequest is
+// called once per page and must return a fresh request; the page parameter is
+// managed by the Pager, page_size is kept if set and defaults to DefaultPageSize.
+func NewPager[T any](ctx context.Context, newRequest func() *Request) *Pager[T] {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &Pager[T]{
+		ctx:        ctx,
+		newRequest: newRequest,
+	}
+}
+
+// WithPrefetch allows up to pages requests to be in flight at the same time once the
+// total number of pages is known. It must be called before the first call to Next.
+func (p *Pager[T]) WithPrefetch(pages int) *Pager[T] {
+	p.prefetch = pages
+	return p
+}
+
+// Next advances to the next item, fetching the next page when needed. It returns
+// false when all pages have been consumed or an error occurred.
+func (p *Pager[T]) Next() bool {
+	for p.index >= len(p.items) {
+		if p.err != nil || p.done {
+			return false
+		}
+		p.fetch()
+	}
+	p.index++
+	return true
+}
+
+// Item returns the item Next advanced to.
+func (p *Pager[T]) Item() T {
+	return p.items[p.index-1]
+}
+
+// Err returns the first error met while fetching pages.
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// Total returns the X-Total-Count reported by the server with the first page.
+func (p *Pager[T]) Total() (int64, bool) {
+	return p.total, p.hasTotal
+}
+
+// All drains the pager and returns every remaining item.
+func (p *Pager[T]) All() ([]T, error) {
+	var all []T
+	for p.Next() {
+		all = append(all, p.Item())
+	}
+	return all, p.err
+}
+
+func (p *Pager[T]) fetch() {
+	p.items, p.index = nil, 0
+	if p.page == 0 {
+		p.fetchFirst()
+		return
+	}
+	switch {
+	case p.lastPage > 0 && p.prefetch > 1:
+		p.fetchPrefetched()
+	case len(p.next) > 0:
+		p.fetchLink()
+	case p.lastPage > 0 && p.page < p.lastPage:
+		p.items, _, p.err = p.get(p.withPage(p.newRequest(), p.page+1))
+		p.page++
+		p.done = p.page >= p.lastPage
+	default:
+		p.done = true
+	}
+	if len(p.items) == 0 && p.err == nil {
+		// Never spin on an empty page, whatever the headers claim.
+		p.done = true
+	}
+}
+
+func (p *Pager[T]) fetchFirst() {
+	r := p.newRequest()
+	if size, err := strconv.ParseInt(r.params.Get("page_size"), 10, 64); err == nil && size > 0 {
+		p.pageSize = size
+	} else {
+		p.pageSize = DefaultPageSize
+	}
+	items, result, err := p.get(p.withPage(r, 1))
+	if err != nil {
+		p.err = err
+		return
+	}
+	p.items, p.page = items, 1
+	p.next = result.NextLink()
+	if total, ok := result.TotalCount(); ok {
+		p.total, p.hasTotal = total, true
+		p.lastPage = (total + p.pageSize - 1) / p.pageSize
+	}
+	p.done = len(items) == 0 || (len(p.next) == 0 && p.page >= p.lastPage)
+}
+
+func (p *Pager[T]) fetchLink() {
+	items, result, err := p.get(p.newRequest().FollowLink(p.next))
+	if err != nil {
+		p.err = err
+		return
+	}
+	p.items = items
+	if page, err := strconv.ParseInt(linkParam(p.next, "page"), 10, 64); err == nil {
+		p.page = page
+	} else {
+		p.page++
+	}
+	p.next = result.NextLink()
+	p.done = len(p.next) == 0
+}
+
+func (p *Pager[T]) fetchPrefetched() {
+	for next := p.page + int64(len(p.pending)) + 1; len(p.pending) < p.prefetch && next <= p.lastPage; next++ {
+		ch := make(chan pageResult[T], 1)
+		go func(r *Request) {
+			items, _, err := p.get(r)
+			ch <- pageResult[T]{items: items, err: err}
+		}(p.withPage(p.newRequest(), next))
+		p.pending = append(p.pending, ch)
+	}
+	if len(p.pending) == 0 {
+		p.done = true
+		return
+	}
+	res := <-p.pending[0]
+	p.pending = p.pending[1:]
+	p.items, p.err = res.items, res.err
+	p.page++
+	p.done = p.page >= p.lastPage
+}
+
+// withPage points r at the given page number.
+func (p *Pager[T]) withPage(r *Request, page int64) *Request {
+	if r.params == nil {
+		r.params = make(url.Values)
+	}
+	r.params.Set("page", strconv.FormatInt(page, 10))
+	r.params.Set("page_size", strconv.FormatInt(p.pageSize, 10))
+	return r
+}
+
+func (p *Pager[T]) get(r *Request) ([]T, Result, error) {
+	result := r.Context(p.ctx).Do()
+	var items []T
+	if err := result.Into(&items); err != nil {
+		return nil, result, err
+	}
+	return items, result, nil
+}
+
+func linkParam(link, key string) string {
+	locator, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return locator.Query().Get(key)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+// newPagedServer serves the integers [0, total) the way Harbor pages its list endpoints.
+func newPagedServer(t *testing.T, total int, withTotal, withLink bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v2.0/projects" {
+			t.Errorf("unexpected path %s", req.URL.Path)
+		}
+		page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+		size, _ := strconv.Atoi(req.URL.Query().Get("page_size"))
+		items := []int{}
+		for i := (page - 1) * size; i < page*size && i < total; i++ {
+			items = append(items, i)
+		}
+		if withTotal {
+			w.Header().Set("X-Total-Count", strconv.Itoa(total))
+		}
+		if withLink && page*size < total {
+			w.Header().Set("Link", fmt.Sprintf(`</api/v2.0/projects?page=%d&page_size=%d&q=name%%3D~a>; rel="next"`, page+1, size))
+		}
+		json.NewEncoder(w).Encode(items)
+	}))
+}
+
+func newTestPager(t *testing.T, server *httptest.Server, pageSize string) *Pager[int] {
+	u, _ := url.Parse(server.URL)
+	return NewPager[int](context.Background(), func() *Request {
+		r := NewRequest(server.Client(), "GET", u, nil, DefaultVersionApiPath, ContentConfig{}, nil, 0).
+			Resource("projects").
+			Param("q", "name=~a")
+		if len(pageSize) > 0 {
+			r.Param("page_size", pageSize)
+		}
+		return r
+	})
+}
+
+func assertSequence(t *testing.T, items []int, err error, total int) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != total {
+		t.Fatalf("expected %d items, got %d", total, len(items))
+	}
+	for i, item := range items {
+		if item != i {
+			t.Fatalf("items out of order at %d: %v", i, items)
+		}
+	}
+}
+
+func TestPagerFollowsLinks(t *testing.T) {
+	server := newPagedServer(t, 25, false, true)
+	defer server.Close()
+
+	items, err := newTestPager(t, server, "10").All()
+	assertSequence(t, items, err, 25)
+}
+
+func TestPagerUsesTotalCountWithoutLinks(t *testing.T) {
+	server := newPagedServer(t, 25, true, false)
+	defer server.Close()
+
+	pager := newTestPager(t, server, "")
+	items, err := pager.All()
+	assertSequence(t, items, err, 25)
+	if total, ok := pager.Total(); !ok || total != 25 {
+		t.Errorf("unexpected total %d %v", total, ok)
+	}
+}
+
+func TestPagerPrefetch(t *testing.T) {
+	server := newPagedServer(t, 95, true, true)
+	defer server.Close()
+
+	items, err := newTestPager(t, server, "10").WithPrefetch(4).All()
+	assertSequence(t, items, err, 95)
+}
+
+func TestPagerEmpty(t *testing.T) {
+	server := newPagedServer(t, 0, true, true)
+	defer server.Close()
+
+	pager := newTestPager(t, server, "10")
+	if pager.Next() {
+		t.Error("an empty listing should not yield items")
+	}
+	if pager.Err() != nil {
+		t.Errorf("unexpected error: %v", pager.Err())
+	}
+}
+
+func TestResultNextLink(t *testing.T) {
+	r := Result{header: http.Header{"Link": []string{`</api/v2.0/projects?page=1&page_size=10>; rel="prev" , </api/v2.0/projects?page=3&page_size=10>; rel="next"`}}}
+	if link := r.NextLink(); link != "/api/v2.0/projects?page=3&page_size=10" {
+		t.Errorf("unexpected next link %q", link)
+	}
+	if link := (Result{}).NextLink(); link != "" {
+		t.Errorf("unexpected next link %q", link)
+	}
+}
@@ -103,6 +103,7 @@ type Result struct {
 	contentType string
 	err         error
 	statusCode  int
+	header      http.Header
 }
 
 type ContentConfig struct {
@@ -186,6 +187,13 @@ func (r *Request) SetHeader(key string, values ...string) *Request {
 	return r
 }
 
+// Context adds a context to the request. Contexts are only used for
+// timeouts, deadlines, and cancellations.
+func (r *Request) Context(ctx context.Context) *Request {
+	r.ctx = ctx
+	return r
+}
+
 // Timeout makes the request use the given duration as an overall timeout for the
 // request. Additionally, if set passes the value as "timeout" parameter in URL.
 func (r *Request) Timeout(d time.Duration) *Request {
@@ -282,6 +290,35 @@ func (r *Request) RequestURI(uri string) *Request {
 	return r
 }
 
+// FollowLink points r at link, replacing the path segments and parameters set so far.
+// link is either server relative, such as the links Harbor returns in the Link header
+// or in the addition links of an artifact, or an absolute URL. Absolute URLs must
+// point at the host of the client so that credentials are never sent elsewhere.
+func (r *Request) FollowLink(link string) *Request {
+	if r.err != nil {
+		return r
+	}
+	locator, err := url.Parse(link)
+	if err != nil {
+		r.err = err
+		return r
+	}
+	if locator.IsAbs() {
+		if r.baseURL == nil || !strings.EqualFold(locator.Scheme, r.baseURL.Scheme) || !strings.EqualFold(locator.Host, r.baseURL.Host) {
+			r.err = fmt.Errorf("refusing to follow link %q outside of the Harbor host", link)
+			return r
+		}
+		locator = &url.URL{Path: locator.Path, RawQuery: locator.RawQuery}
+	} else if r.baseURL != nil && len(r.baseURL.Path) > 1 && !strings.HasPrefix(locator.Path, strings.TrimSuffix(r.baseURL.Path, "/")+"/") {
+		// Harbor builds links without the prefix of a proxied base URL, restore it.
+		locator.Path = path.Join(r.baseURL.Path, locator.Path)
+	}
+	r.project, r.projectSet = "", false
+	r.resource, r.resourceName, r.subresource, r.subpath = "", "", "", ""
+	r.params = nil
+	return r.RequestURI(locator.String())
+}
+
 // glogBody logs a body output that could be either JSON or protobuf. It explicitly guards against
 // allocating a new string for the body output unless necessary. Uses a simple heuristic to determine
 // whether the body is printable.
@@ -346,6 +383,18 @@ func (r *Request) URL() *url.URL {
 	return finalURL
 }
 
+// HeaderIsResourceName tells Harbor whether {project_name_or_id} is a name, so that
+// a project named "15" is not mistaken for the project with ID 15.
+const HeaderIsResourceName = "X-Is-Resource-Name"
+
+// ProjectByName points r at the project called name, telling Harbor it is a name
+// and not an ID.
+func (r *Request) ProjectByName(name string) *Request {
+	return r.SetHeader(HeaderIsResourceName, "true").
+		Resource("projects").
+		Name(name)
+}
+
 // Project applies the namespace scope to a request (<resource>/[ns/<namespace>/]<name>)
 func (r *Request) Project(project string) *Request {
 	if r.err != nil {
@@ -394,10 +443,13 @@ func (r *Request) tryThrottle() error {
 	if r.throttle == nil {
 		return nil
 	}
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
 	now := time.Now()
-	var err error
-	r.throttle.Accept()
+	err := r.throttle.Wait(ctx)
 	if latency := time.Since(now); latency > longThrottleLatency {
 		klog.V(4).Infof("Throttling request took %v, request: %s:%s", latency, r.verb, r.URL().String())
 	}
@@ -411,7 +463,7 @@ func (r *Request) tryThrottle() error {
 // Error type:
 //  * If the request can't be constructed, or an error happened earlier while building its
 //    arguments: *RequestConstructionError
-//  * If the server responds with a status: *errors.StatusError or *errors.UnexpectedObjectError
+//  * If the server responds with a non 2xx status: *StatusError
 //  * http.Client.Do errors are returned directly.
 func (r *Request) Do() Result {
 	if err := r.tryThrottle(); err != nil {
@@ -428,6 +480,69 @@ func (r *Request) Do() Result {
 	return result
 }
 
+// Stream formats and executes the request, and offers streaming of the response.
+// Returns io.ReadCloser which could be used for streaming of the response, or an error.
+// The caller must close the stream. If the server answered with an error status, the
+// returned error is a *StatusError.
+//
+// The stream is only bound by the context of the request and by Timeout, not by the
+// overall timeout of the http.Client, which would cut off slow streams such as logs.
+func (r *Request) Stream() (io.ReadCloser, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if err := r.tryThrottle(); err != nil {
+		return nil, err
+	}
+
+	if client, ok := r.client.(*http.Client); ok && client.Timeout > 0 {
+		withoutTimeout := *client
+		withoutTimeout.Timeout = 0
+		r.client = &withoutTimeout
+	}
+	// request() cancels its timeout when it returns, the stream has to outlive it.
+	cancel := context.CancelFunc(func() {})
+	if r.timeout > 0 {
+		if r.ctx == nil {
+			r.ctx = context.Background()
+		}
+		r.ctx, cancel = context.WithTimeout(r.ctx, r.timeout)
+		r.timeout = 0
+	}
+
+	var stream io.ReadCloser
+	var err error
+	reqErr := r.request(func(req *http.Request, resp *http.Response) {
+		if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+			// Take over the body, request() would otherwise drain and close it.
+			stream = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			resp.Body = http.NoBody
+			return
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		err = r.transformUnstructuredResponseError(resp, req, body)
+	})
+	if reqErr != nil {
+		err = reqErr
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return stream, nil
+}
+
+// cancelOnClose releases the context of a stream once the stream is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
 // request connects to the server and invokes the provided function when a server response is
 // received. It handles retry behavior and up front validation of requests. It will invoke
 // fn at most once. It will return an error if a problem occurred prior to connecting to the
@@ -483,6 +598,10 @@ func (r *Request) request(fn func(*http.Request, *http.Response)) error {
 		}
 		resp, err := client.Do(req)
 		if err != nil {
+			// A cancelled or expired context must not be retried.
+			if r.ctx != nil && r.ctx.Err() != nil {
+				return err
+			}
 			// For the purpose of retry, we set the artificial "retry-after" response.
 			// TODO: Should we clean the original response if it exists?
 			resp = &http.Response{
@@ -596,12 +715,13 @@ func (r *Request) transformUnstructuredResponseError(resp *http.Response, req *h
 		}
 	}
 	//retryAfter, _ := retryAfterSeconds(resp)
-	return r.newUnstructuredResponseError(body, resp.StatusCode, req)
+	return r.newUnstructuredResponseError(body, resp, req)
 }
 
-// newUnstructuredResponseError instantiates the appropriate generic error for the provided input. It also logs the body.
-func (r *Request) newUnstructuredResponseError(body []byte, statusCode int, req *http.Request) error {
-	return fmt.Errorf("%s url:%s StatusCode: %d", req.Method, req.URL.Path, statusCode)
+// newUnstructuredResponseError instantiates a *StatusError for the provided input, decoding
+// Harbor's error envelope out of body when present.
+func (r *Request) newUnstructuredResponseError(body []byte, resp *http.Response, req *http.Request) error {
+	return newStatusError(body, resp, req)
 }
 
 // transformResponse converts an API response into a structured API object
@@ -646,11 +766,12 @@ func (r *Request) transformResponse(resp *http.Response, req *http.Request) Resu
 		// calculate an unstructured error from the response which the Result object may use if the caller
 		// did not return a structured error.
 		//retryAfter, _ := retryAfterSeconds(resp)
-		err := r.newUnstructuredResponseError(body, resp.StatusCode, req)
+		err := r.newUnstructuredResponseError(body, resp, req)
 		return Result{
 			body:        body,
 			contentType: contentType,
 			statusCode:  resp.StatusCode,
+			header:      resp.Header,
 			err:         err,
 		}
 	}
@@ -659,6 +780,7 @@ func (r *Request) transformResponse(resp *http.Response, req *http.Request) Resu
 		body:        body,
 		contentType: contentType,
 		statusCode:  resp.StatusCode,
+		header:      resp.Header,
 	}
 }
 
@@ -695,28 +817,99 @@ func truncateBody(body string) string {
 }
 
 // Into stores the result into obj, if possible. If obj is nil it is ignored.
-// If the returned object is of type Status and has .Status != StatusSuccess, the
-// additional information in Status will be used to enrich the error.
+// If the server answered with an error status, the returned error is a *StatusError
+// carrying the details Harbor sent back.
 func (r Result) Into(obj interface{}) error {
 	if r.err != nil {
-		// Check whether the result has a Status object in the body and prefer that.
-		if r.contentType == "application/json; charset=utf-8" {
-			return fmt.Errorf("%v message:%s", r.err, string(r.body))
-		}
 		return r.err
 	}
+	if obj == nil || len(r.body) == 0 {
+		return nil
+	}
 	return json.Unmarshal(r.body, obj)
 }
 
+// Error returns the error executing the request, nil if no error occurred.
+// See the Into() documentation for the error types that may be returned.
 func (r Result) Error() error {
+	return r.err
+}
+
+// Raw returns the raw body of the response and the error of the request, if any.
+func (r Result) Raw() ([]byte, error) {
+	return r.body, r.err
+}
+
+// ContentType returns the Content-Type of the response.
+func (r Result) ContentType() string {
+	return r.contentType
+}
+
+// StatusCode returns the HTTP status code of the response, 0 if no response was received.
+func (r Result) StatusCode() int {
+	return r.statusCode
+}
+
+// Location returns the Location header Harbor sets on the resources it creates.
+func (r Result) Location() string {
+	if r.header == nil {
+		return ""
+	}
+	return r.header.Get("Location")
+}
+
+// CreatedID returns the numeric ID ending the Location header of a creation
+// response, or the error of the request if it failed.
+func (r Result) CreatedID() (int64, error) {
 	if r.err != nil {
-		// Check whether the result has a Status object in the body and prefer that.
-		if r.contentType == "application/json; charset=utf-8" {
-			return fmt.Errorf("%v message:%s", r.err, string(r.body))
+		return 0, r.err
+	}
+	location := r.Location()
+	id, err := strconv.ParseInt(path.Base(location), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected location %q: %v", location, err)
+	}
+	return id, nil
+}
+
+// Header returns the response headers, nil if no response was received.
+func (r Result) Header() http.Header {
+	return r.header
+}
+
+// TotalCount returns the value of the X-Total-Count header Harbor sets on list
+// responses, and false if the header is missing or not a number.
+func (r Result) TotalCount() (int64, bool) {
+	if r.header == nil {
+		return 0, false
+	}
+	total, err := strconv.ParseInt(r.header.Get("X-Total-Count"), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return total, true
+}
+
+// NextLink returns the server relative URI of the next page announced by the
+// Link header, or an empty string on the last page.
+func (r Result) NextLink() string {
+	if r.header == nil {
+		return ""
+	}
+	for _, header := range r.header.Values("Link") {
+		for _, link := range strings.Split(header, ",") {
+			parts := strings.Split(link, ";")
+			if len(parts) < 2 {
+				continue
+			}
+			for _, param := range parts[1:] {
+				if strings.TrimSpace(param) == `rel="next"` {
+					return strings.Trim(strings.TrimSpace(parts[0]), "<>")
+				}
+			}
 		}
-		return r.err
 	}
-	return nil
+	return ""
 }
 
 func (r *Request) Params(o interface{}) *Request {
@@ -16,11 +16,18 @@ See the License for the specific language governing permissions and
 package rest
 
 import (
+	"context"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"reflect"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	flowcontrol2 "github.com/TimeBye/go-harbor/pkg/rest/util/flowcontrol"
 )
 
 func TestNewRequestSetsAccept(t *testing.T) {
@@ -113,6 +120,45 @@ func TestRequestURI(t *testing.T) {
 	}
 }
 
+func TestRequestFollowLink(t *testing.T) {
+	base := &url.URL{Scheme: "https", Host: "harbor.example.com", Path: "/proxy"}
+	links := map[string]string{
+		"/api/v2.0/projects/p/repositories/r/artifacts/sha256:1/additions/readme.md":                         "https://harbor.example.com/proxy/api/v2.0/projects/p/repositories/r/artifacts/sha256:1/additions/readme.md",
+		"https://harbor.example.com/proxy/api/v2.0/projects/p/repositories/r/artifacts/sha256:1/additions/x": "https://harbor.example.com/proxy/api/v2.0/projects/p/repositories/r/artifacts/sha256:1/additions/x",
+	}
+	for link, expected := range links {
+		r := (&Request{baseURL: base}).Project("other").Resource("users").FollowLink(link)
+		if r.err != nil {
+			t.Fatalf("%s: unexpected error: %v", link, r.err)
+		}
+		if s := r.URL().String(); s != expected {
+			t.Errorf("%s: expected %s, got %s", link, expected, s)
+		}
+	}
+
+	r := (&Request{baseURL: base}).FollowLink("https://elsewhere.example.com/api/v2.0/users")
+	if r.err == nil {
+		t.Errorf("expected links to other hosts to be refused")
+	}
+}
+
+func TestRequestNameKeepsEscapedSlash(t *testing.T) {
+	r := (&Request{baseURL: &url.URL{}}).Project("p").Resource("repositories").Name("a%2Fb").Suffix("artifacts")
+	if s := r.URL().String(); s != "projects/p/repositories/a%252Fb/artifacts" {
+		t.Errorf("escaped slash should be encoded once more: %s", s)
+	}
+}
+
+func TestRequestProjectByName(t *testing.T) {
+	r := (&Request{baseURL: &url.URL{}}).ProjectByName("15").Suffix("metadatas")
+	if s := r.URL().String(); s != "projects/15/metadatas" {
+		t.Errorf("unexpected URL %s", s)
+	}
+	if h := r.headers.Get(HeaderIsResourceName); h != "true" {
+		t.Errorf("expected the project to be referenced by name, got %q", h)
+	}
+}
+
 type NotAnAPIObject struct{}
 
 func TestRequestBody(t *testing.T) {
@@ -141,3 +187,136 @@ func TestRequestBody(t *testing.T) {
 		t.Errorf("should have set err and left body nil: %#v", r)
 	}
 }
+
+func TestRequestContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := (&Request{}).Context(ctx)
+	if r.ctx != ctx {
+		t.Errorf("context should be set: %#v", r)
+	}
+}
+
+func TestRequestThrottleHonorsContext(t *testing.T) {
+	throttle := flowcontrol2.NewFakeNeverRateLimiter()
+	defer throttle.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	r := NewRequest(nil, "GET", &url.URL{Path: "/"}, nil, "", ContentConfig{}, throttle, 0).Context(ctx)
+	if err := r.Do().Error(); err != context.DeadlineExceeded {
+		t.Errorf("expected the throttle to give up with the context, got %v", err)
+	}
+}
+
+func TestRequestCancelledContextIsNotRetried(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-req.Context().Done()
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := NewRequest(server.Client(), "GET", u, nil, "", ContentConfig{}, nil, 0).
+		Context(ctx).
+		Do().
+		Error()
+	if err == nil {
+		t.Fatal("expected an error for the expired context")
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("expected exactly one attempt, got %d", n)
+	}
+}
+
+func TestRequestStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[{"code":"NOT_FOUND","message":"log not found"}]}`))
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("line 1\nline 2\n"))
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	stream, err := NewRequest(server.Client(), "GET", u, nil, "", ContentConfig{}, nil, 0).AbsPath("/log").Stream()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+	data, err := ioutil.ReadAll(stream)
+	if err != nil || string(data) != "line 1\nline 2\n" {
+		t.Errorf("unexpected stream content %q: %v", data, err)
+	}
+
+	_, err = NewRequest(server.Client(), "GET", u, nil, "", ContentConfig{}, nil, 0).AbsPath("/missing").Stream()
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestRequestStreamOutlivesClientTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("line 1\n"))
+		w.(http.Flusher).Flush()
+		time.Sleep(300 * time.Millisecond)
+		w.Write([]byte("line 2\n"))
+	}))
+	defer server.Close()
+
+	client, err := RESTClientFor(&Config{Host: server.URL, Timeout: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream, err := client.Get().AbsPath("/log").Stream()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+	data, err := ioutil.ReadAll(stream)
+	if err != nil || string(data) != "line 1\nline 2\n" {
+		t.Errorf("unexpected stream content %q: %v", data, err)
+	}
+	if client.Client.Timeout != 100*time.Millisecond {
+		t.Errorf("the client timeout must be left untouched, got %v", client.Client.Timeout)
+	}
+
+	stream, err = client.Get().AbsPath("/log").Timeout(100 * time.Millisecond).Stream()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+	if _, err := ioutil.ReadAll(stream); err == nil {
+		t.Error("expected the request timeout to bound the stream")
+	}
+}
+
+func TestRequestStreamRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("log"))
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	stream, err := NewRequest(server.Client(), "GET", u, nil, "", ContentConfig{}, nil, 0).AbsPath("/log").Stream()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+	data, _ := ioutil.ReadAll(stream)
+	if string(data) != "log" || atomic.LoadInt32(&calls) != 2 {
+		t.Errorf("expected the stream to be retried, got %q after %d calls", data, calls)
+	}
+}
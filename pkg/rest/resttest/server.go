@@ -0,0 +1,148 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+// Package resttest provides a fake Harbor API server to test the typed clients
+// against, recording every request it receives.
+package resttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
+)
+
+// Request is a request received by the Server.
+type Request struct {
+	Method string
+	// Path is the escaped path of the request, e.g.
+	// "/api/v2.0/projects/library/repositories/a%252Fb".
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Decode unmarshals the JSON body of the request into v.
+func (r *Request) Decode(t *testing.T, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		t.Fatalf("%s %s: decode body %q: %v", r.Method, r.Path, r.Body, err)
+	}
+}
+
+// Server answers the requests matching the routes registered with Handle and
+// Reply, and 404 to the others.
+type Server struct {
+	*httptest.Server
+
+	t        *testing.T
+	mu       sync.Mutex
+	routes   map[string]http.HandlerFunc
+	requests []*Request
+}
+
+// NewServer starts a Server, closed when the test ends.
+func NewServer(t *testing.T) *Server {
+	s := &Server{t: t, routes: map[string]http.HandlerFunc{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Config returns a Config pointing the clients at the server.
+func (s *Server) Config() *rest2.Config {
+	return rest2.NewDefaultConfig(s.URL, "admin", "Harbor12345")
+}
+
+// Handle routes the requests with method and the escaped path to handler. path
+// is relative to the API path, e.g. "/projects/library".
+func (s *Server) Handle(method, path string, handler http.HandlerFunc) {
+	s.HandleAbs(method, rest2.DefaultVersionApiPath+path, handler)
+}
+
+// HandleAbs is like Handle but path is absolute, e.g. "/v2/library/nginx/referrers/sha256:..."
+// for the registry API.
+func (s *Server) HandleAbs(method, path string, handler http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes[method+" "+path] = handler
+}
+
+// Reply answers the requests with method and the escaped path with status and
+// the JSON body, if any.
+func (s *Server) Reply(method, path string, status int, body string) {
+	s.Handle(method, path, func(w http.ResponseWriter, req *http.Request) {
+		if body != "" {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	})
+}
+
+// ReplyCreated answers the requests with method and the escaped path with 201
+// and a Location header ending with id, as Harbor does on creation.
+func (s *Server) ReplyCreated(method, path string, id string) {
+	s.Handle(method, path, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Location", req.URL.Path+"/"+id)
+		w.WriteHeader(http.StatusCreated)
+	})
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Request(nil), s.requests...)
+}
+
+// Last returns the last request received, failing the test if there is none.
+func (s *Server) Last() *Request {
+	s.t.Helper()
+	requests := s.Requests()
+	if len(requests) == 0 {
+		s.t.Fatal("no request received")
+	}
+	return requests[len(requests)-1]
+}
+
+func (s *Server) serve(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+	key := req.Method + " " + req.URL.EscapedPath()
+	s.mu.Lock()
+	s.requests = append(s.requests, &Request{
+		Method: req.Method,
+		Path:   req.URL.EscapedPath(),
+		Query:  req.URL.Query(),
+		Header: req.Header.Clone(),
+		Body:   body,
+	})
+	handler, ok := s.routes[key]
+	s.mu.Unlock()
+	if !ok {
+		s.t.Errorf("unexpected request %s", key)
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"errors":[{"code":"NOT_FOUND","message":"no route for %s"}]}`, key)
+		return
+	}
+	handler(w, req)
+}
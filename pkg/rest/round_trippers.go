@@ -0,0 +1,232 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package rest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TimeBye/go-harbor/pkg/rest/util/clock"
+	"k8s.io/klog"
+)
+
+const (
+	// DefaultRobotPrefix is the prefix Harbor puts in front of robot account names
+	// unless robot_name_prefix is configured otherwise.
+	DefaultRobotPrefix = "robot$"
+
+	// fileRefreshPeriod is how long a value read from a token or secret file is
+	// trusted before the file is read again.
+	fileRefreshPeriod = time.Minute
+)
+
+// HTTPWrappersForConfig wraps a round tripper with any relevant layered
+// behavior from the config. Exposed to allow more clients that need HTTP-like
+// behavior but then must hijack the underlying connection (like WebSocket or
+// HTTP2 clients). Pure HTTP clients should use the RoundTripper returned from
+// TransportFor.
+func HTTPWrappersForConfig(config *Config, rt http.RoundTripper) (http.RoundTripper, error) {
+	authMethods := 0
+	if config.HasBearerAuth() {
+		authMethods++
+	}
+	if config.HasRobotAuth() {
+		authMethods++
+	}
+	if config.HasBasicAuth() {
+		authMethods++
+	}
+	if authMethods > 1 {
+		return nil, fmt.Errorf("only one of username/password, robot account or bearer token may be set")
+	}
+
+	switch {
+	case config.HasBearerAuth():
+		return NewBearerAuthWithRefreshRoundTripper(config.BearerToken, config.BearerTokenFile, rt), nil
+	case config.HasRobotAuth():
+		return NewRobotAuthRoundTripper(config.RobotName, config.RobotSecret, config.RobotSecretFile, rt), nil
+	case config.HasBasicAuth():
+		return NewBasicAuthRoundTripper(config.Username, config.Password, rt), nil
+	}
+	return rt, nil
+}
+
+// HasBasicAuth returns whether the configuration has basic authentication or not.
+func (c *Config) HasBasicAuth() bool {
+	return len(c.Username) != 0 && len(c.Password) != 0
+}
+
+// HasBearerAuth returns whether the configuration has bearer token authentication or not.
+func (c *Config) HasBearerAuth() bool {
+	return len(c.BearerToken) != 0 || len(c.BearerTokenFile) != 0
+}
+
+// HasRobotAuth returns whether the configuration authenticates as a robot account or not.
+func (c *Config) HasRobotAuth() bool {
+	return len(c.RobotName) != 0
+}
+
+type basicAuthRoundTripper struct {
+	username string
+	password string
+	rt       http.RoundTripper
+}
+
+// NewBasicAuthRoundTripper will apply a BASIC auth authorization header to a
+// request unless it has already been set.
+func NewBasicAuthRoundTripper(username, password string, rt http.RoundTripper) http.RoundTripper {
+	return &basicAuthRoundTripper{username, password, rt}
+}
+
+func (rt *basicAuthRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(req.Header.Get("Authorization")) != 0 {
+		return rt.rt.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.SetBasicAuth(rt.username, rt.password)
+	return rt.rt.RoundTrip(req)
+}
+
+type bearerAuthRoundTripper struct {
+	bearer string
+	source *cachedFileSource
+	rt     http.RoundTripper
+}
+
+// NewBearerAuthRoundTripper adds the provided bearer token to a request
+// unless the authorization header has already been set.
+func NewBearerAuthRoundTripper(bearer string, rt http.RoundTripper) http.RoundTripper {
+	return &bearerAuthRoundTripper{bearer: bearer, rt: rt}
+}
+
+// NewBearerAuthWithRefreshRoundTripper adds the provided bearer token to a request
+// unless the authorization header has already been set.
+// If tokenFile is non-empty, it is periodically read,
+// and the last successfully read content is used as the bearer token.
+// If tokenFile is non-empty and bearer is empty, the tokenFile is read
+// immediately to populate the initial bearer token.
+func NewBearerAuthWithRefreshRoundTripper(bearer string, tokenFile string, rt http.RoundTripper) http.RoundTripper {
+	if len(tokenFile) == 0 {
+		return NewBearerAuthRoundTripper(bearer, rt)
+	}
+	source := newCachedFileSource(tokenFile, clock.RealClock{})
+	if len(bearer) == 0 {
+		if _, err := source.value(); err != nil {
+			klog.Errorf("Unable to read bearer token file %q: %v", tokenFile, err)
+		}
+	}
+	return &bearerAuthRoundTripper{bearer: bearer, source: source, rt: rt}
+}
+
+func (rt *bearerAuthRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(req.Header.Get("Authorization")) != 0 {
+		return rt.rt.RoundTrip(req)
+	}
+	token := rt.bearer
+	if rt.source != nil {
+		if refreshed, err := rt.source.value(); err == nil {
+			token = refreshed
+		} else if len(token) == 0 {
+			return nil, err
+		}
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	return rt.rt.RoundTrip(req)
+}
+
+type robotAuthRoundTripper struct {
+	name   string
+	secret string
+	source *cachedFileSource
+	rt     http.RoundTripper
+}
+
+// NewRobotAuthRoundTripper authenticates requests as the Harbor robot account name,
+// adding DefaultRobotPrefix to name when it carries no "$" separator. If secretFile is
+// non-empty it is periodically read and its last successfully read content takes
+// precedence over secret, so that rotated secrets are picked up without a restart.
+func NewRobotAuthRoundTripper(name, secret, secretFile string, rt http.RoundTripper) http.RoundTripper {
+	if !strings.Contains(name, "$") {
+		name = DefaultRobotPrefix + name
+	}
+	robot := &robotAuthRoundTripper{name: name, secret: secret, rt: rt}
+	if len(secretFile) > 0 {
+		robot.source = newCachedFileSource(secretFile, clock.RealClock{})
+	}
+	return robot
+}
+
+func (rt *robotAuthRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(req.Header.Get("Authorization")) != 0 {
+		return rt.rt.RoundTrip(req)
+	}
+	secret := rt.secret
+	if rt.source != nil {
+		if refreshed, err := rt.source.value(); err == nil {
+			secret = refreshed
+		} else if len(secret) == 0 {
+			return nil, err
+		}
+	}
+	req = req.Clone(req.Context())
+	req.SetBasicAuth(rt.name, secret)
+	return rt.rt.RoundTrip(req)
+}
+
+// cachedFileSource reads a credential out of a file, caching it for
+// fileRefreshPeriod. When the file cannot be read the last good value is kept.
+type cachedFileSource struct {
+	path  string
+	clock clock.PassiveClock
+
+	mu     sync.Mutex
+	cached string
+	expiry time.Time
+}
+
+func newCachedFileSource(path string, c clock.PassiveClock) *cachedFileSource {
+	return &cachedFileSource{path: path, clock: c}
+}
+
+func (s *cachedFileSource) value() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	if len(s.cached) > 0 && now.Before(s.expiry) {
+		return s.cached, nil
+	}
+	data, err := ioutil.ReadFile(s.path)
+	if err == nil && len(strings.TrimSpace(string(data))) == 0 {
+		err = fmt.Errorf("read empty value from file %q", s.path)
+	}
+	if err != nil {
+		if len(s.cached) > 0 {
+			klog.V(2).Infof("Unable to refresh %q, keeping the last value: %v", s.path, err)
+			s.expiry = now.Add(fileRefreshPeriod)
+			return s.cached, nil
+		}
+		return "", err
+	}
+	s.cached = strings.TrimSpace(string(data))
+	s.expiry = now.Add(fileRefreshPeriod)
+	return s.cached, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package rest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TimeBye/go-harbor/pkg/rest/util/clock"
+)
+
+type testRoundTripper struct {
+	Request *http.Request
+}
+
+func (rt *testRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.Request = req
+	return &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func writeFile(t *testing.T, path, content string) {
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBearerAuthRoundTripper(t *testing.T) {
+	rt := &testRoundTripper{}
+	req := &http.Request{Header: http.Header{}}
+	NewBearerAuthRoundTripper("test", rt).RoundTrip(req)
+	if rt.Request == nil || rt.Request == req {
+		t.Fatalf("unexpected request: %#v", rt.Request)
+	}
+	if rt.Request.Header.Get("Authorization") != "Bearer test" {
+		t.Errorf("unexpected authorization header: %#v", rt.Request.Header)
+	}
+	if len(req.Header.Get("Authorization")) != 0 {
+		t.Error("the original request must not be modified")
+	}
+}
+
+func TestRobotAuthRoundTripper(t *testing.T) {
+	for _, name := range []string{"ci", "robot$ci"} {
+		rt := &testRoundTripper{}
+		NewRobotAuthRoundTripper(name, "secret", "", rt).RoundTrip(&http.Request{Header: http.Header{}})
+		user, pass, ok := rt.Request.BasicAuth()
+		if !ok || user != "robot$ci" || pass != "secret" {
+			t.Errorf("%s: unexpected credentials %q %q", name, user, pass)
+		}
+	}
+}
+
+func TestCachedFileSourceReloads(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-harbor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "token")
+	writeFile(t, path, "first\n")
+
+	fakeClock := clock.NewFakePassiveClock(time.Now())
+	source := newCachedFileSource(path, fakeClock)
+	if v, err := source.value(); err != nil || v != "first" {
+		t.Fatalf("unexpected value %q: %v", v, err)
+	}
+
+	writeFile(t, path, "second")
+	if v, _ := source.value(); v != "first" {
+		t.Errorf("value should be cached until the refresh period elapses, got %q", v)
+	}
+	fakeClock.SetTime(fakeClock.Now().Add(fileRefreshPeriod))
+	if v, _ := source.value(); v != "second" {
+		t.Errorf("value should be reloaded after the refresh period, got %q", v)
+	}
+
+	os.Remove(path)
+	fakeClock.SetTime(fakeClock.Now().Add(fileRefreshPeriod))
+	if v, err := source.value(); err != nil || v != "second" {
+		t.Errorf("the last good value should be kept when the file disappears, got %q: %v", v, err)
+	}
+}
+
+func TestHTTPWrappersForConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-harbor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "secret")
+	writeFile(t, path, "from-file")
+
+	cases := []struct {
+		config *Config
+		check  func(*http.Request) bool
+	}{
+		{NewDefaultConfig("harbor", "admin", "pass"), func(req *http.Request) bool {
+			user, pass, _ := req.BasicAuth()
+			return user == "admin" && pass == "pass"
+		}},
+		{&Config{BearerTokenFile: path}, func(req *http.Request) bool {
+			return req.Header.Get("Authorization") == "Bearer from-file"
+		}},
+		{NewRobotConfig("harbor", "robot$ci", "stale", path), func(req *http.Request) bool {
+			user, pass, _ := req.BasicAuth()
+			return user == "robot$ci" && pass == "from-file"
+		}},
+	}
+	for i, c := range cases {
+		rt := &testRoundTripper{}
+		wrapped, err := HTTPWrappersForConfig(c.config, rt)
+		if err != nil {
+			t.Fatalf("case %d: unexpected error: %v", i, err)
+		}
+		wrapped.RoundTrip(&http.Request{Header: http.Header{}})
+		if !c.check(rt.Request) {
+			t.Errorf("case %d: unexpected headers %#v", i, rt.Request.Header)
+		}
+	}
+
+	if _, err := HTTPWrappersForConfig(&Config{Username: "admin", Password: "pass", BearerToken: "token"}, &testRoundTripper{}); err == nil {
+		t.Error("expected an error when several authentication methods are set")
+	}
+}
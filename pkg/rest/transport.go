@@ -15,12 +15,174 @@ See the License for the specific language governing permissions and
 
 package rest
 
-import "net/http"
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
 
-func TransportFor() *http.Transport {
+// WrapperFunc wraps an http.RoundTripper when a new transport
+// is created for a client, allowing per connection behavior
+// to be injected.
+type WrapperFunc func(rt http.RoundTripper) http.RoundTripper
+
+// Wrappers accepts any number of wrappers and returns a wrapper
+// function that is the equivalent of calling each of them in order. Nil
+// values are ignored, which makes this function convenient for incrementally
+// wrapping a function.
+func Wrappers(fns ...WrapperFunc) WrapperFunc {
+	if len(fns) == 0 {
+		return nil
+	}
+	// optimize the common case of wrapping a possibly nil transport wrapper
+	// with an additional wrapper
+	if len(fns) == 2 && fns[0] == nil {
+		return fns[1]
+	}
+	return func(rt http.RoundTripper) http.RoundTripper {
+		base := rt
+		for _, fn := range fns {
+			if fn != nil {
+				base = fn(base)
+			}
+		}
+		return base
+	}
+}
+
+// HasCA returns whether the configuration has a certificate authority or not.
+func (c *TLSClientConfig) HasCA() bool {
+	return len(c.CAData) > 0 || len(c.CAFile) > 0
+}
+
+// HasCertAuth returns whether the configuration has certificate authentication or not.
+func (c *TLSClientConfig) HasCertAuth() bool {
+	return (len(c.CertData) != 0 || len(c.CertFile) != 0) && (len(c.KeyData) != 0 || len(c.KeyFile) != 0)
+}
+
+// IsEmpty returns true if no transport level security is requested.
+func (c *TLSClientConfig) IsEmpty() bool {
+	return !c.Insecure && len(c.ServerName) == 0 && !c.HasCA() && !c.HasCertAuth() && len(c.NextProtos) == 0
+}
+
+// TLSConfigFor returns a tls.Config that will provide the transport level security defined
+// by the provided Config. Will return nil if no transport level security is requested.
+func TLSConfigFor(config *Config) (*tls.Config, error) {
+	if config.TLSClientConfig.IsEmpty() {
+		return nil, nil
+	}
+	c := config.TLSClientConfig.DeepCopy()
+	if c.HasCA() && c.Insecure {
+		return nil, fmt.Errorf("specifying a root certificates file with the insecure flag is not allowed")
+	}
+	if err := loadTLSFiles(c); err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		// Can't use SSLv3 because of POODLE and BEAST
+		// Can't use TLSv1.0 because of POODLE and BEAST using CBC cipher
+		// Can't use TLSv1.1 because of RC4 cipher usage
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.Insecure,
+		ServerName:         c.ServerName,
+		NextProtos:         c.NextProtos,
+	}
+
+	if c.HasCA() {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(c.CAData) {
+			return nil, fmt.Errorf("unable to load root certificates: no valid certificate found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.HasCertAuth() {
+		cert, err := tls.X509KeyPair(c.CertData, c.KeyData)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// loadTLSFiles copies the data from the CertFile, KeyFile, and CAFile fields into the CertData,
+// KeyData, and CAData fields, or returns an error. If no error is returned, all three fields are
+// either populated or were empty to start.
+func loadTLSFiles(c *TLSClientConfig) error {
+	var err error
+	c.CAData, err = dataFromSliceOrFile(c.CAData, c.CAFile)
+	if err != nil {
+		return err
+	}
+	c.CertData, err = dataFromSliceOrFile(c.CertData, c.CertFile)
+	if err != nil {
+		return err
+	}
+	c.KeyData, err = dataFromSliceOrFile(c.KeyData, c.KeyFile)
+	return err
+}
+
+// dataFromSliceOrFile returns data from the slice (if non-empty), or from the file,
+// or an error if an error occurred reading the file
+func dataFromSliceOrFile(data []byte, file string) ([]byte, error) {
+	if len(data) > 0 {
+		return data, nil
+	}
+	if len(file) > 0 {
+		fileData, err := ioutil.ReadFile(file)
+		if err != nil {
+			return []byte{}, err
+		}
+		return fileData, nil
+	}
+	return nil, nil
+}
+
+// TransportFor returns an http.RoundTripper that will provide the authentication,
+// transport level security, dialer and proxy defined by the provided Config, wrapped
+// by the RoundTrippers registered through WrapTransport.
+func TransportFor(config *Config) (http.RoundTripper, error) {
+	rt, err := baseTransportFor(config)
+	if err != nil {
+		return nil, err
+	}
+	if config.WrapTransport != nil {
+		rt = config.WrapTransport(rt)
+	}
+	return HTTPWrappersForConfig(config, rt)
+}
+
+func baseTransportFor(config *Config) (http.RoundTripper, error) {
+	if config.Transport != nil {
+		// It is an error to set both a custom transport and TLS options: the custom
+		// transport would silently ignore them.
+		if !config.TLSClientConfig.IsEmpty() {
+			return nil, fmt.Errorf("using a custom transport with TLS certificate options or the insecure flag is not allowed")
+		}
+		return config.Transport, nil
+	}
+
+	tlsConfig, err := TLSConfigFor(config)
+	if err != nil {
+		return nil, err
+	}
 	t := http.DefaultTransport.(*http.Transport).Clone()
 	t.MaxIdleConns = 100
 	t.MaxConnsPerHost = 100
 	t.MaxIdleConnsPerHost = 100
-	return t
+	t.DisableCompression = config.DisableCompression
+	if tlsConfig != nil {
+		t.TLSClientConfig = tlsConfig
+	}
+	if config.Dial != nil {
+		t.DialContext = config.Dial
+	}
+	if config.Proxy != nil {
+		t.Proxy = config.Proxy
+	}
+	return t, nil
 }
@@ -0,0 +1,183 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package rest
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+func newTLSTestServer(t *testing.T, clientAuth bool) (*httptest.Server, []byte) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v2.0/projects/demo" {
+			t.Errorf("unexpected path %s", req.URL.Path)
+		}
+		fmt.Fprint(w, `{"name":"demo"}`)
+	}))
+	if clientAuth {
+		// The httptest certificate is only valid for server authentication, so the
+		// handshake merely checks that a client certificate is presented.
+		server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	}
+	server.StartTLS()
+	caData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	return server, caData
+}
+
+func getDemo(config *Config) error {
+	client, err := RESTClientFor(config)
+	if err != nil {
+		return err
+	}
+	result := map[string]string{}
+	return client.Get().Resource("projects").Name("demo").Do().Into(&result)
+}
+
+func TestRESTClientForUsesHostAndCAData(t *testing.T) {
+	server, caData := newTLSTestServer(t, false)
+	defer server.Close()
+
+	if err := getDemo(&Config{Host: server.URL}); err == nil {
+		t.Error("expected the self-signed certificate to be rejected without a CA")
+	}
+	if err := getDemo(&Config{Host: server.URL, TLSClientConfig: TLSClientConfig{CAData: caData}}); err != nil {
+		t.Errorf("unexpected error with CAData: %v", err)
+	}
+	if err := getDemo(&Config{Host: server.URL, TLSClientConfig: TLSClientConfig{Insecure: true}}); err != nil {
+		t.Errorf("unexpected error with Insecure: %v", err)
+	}
+}
+
+func TestRESTClientForLoadsFiles(t *testing.T) {
+	server, caData := newTLSTestServer(t, false)
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "go-harbor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	caFile := filepath.Join(dir, "ca.crt")
+	if err := ioutil.WriteFile(caFile, caData, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &Config{Host: server.URL, TLSClientConfig: TLSClientConfig{CAFile: caFile}}
+	if err := getDemo(config); err != nil {
+		t.Errorf("unexpected error with CAFile: %v", err)
+	}
+	if len(config.CAData) != 0 {
+		t.Error("the caller's config must not be modified")
+	}
+}
+
+func TestRESTClientForClientCertificate(t *testing.T) {
+	server, caData := newTLSTestServer(t, true)
+	defer server.Close()
+
+	// httptest uses the same certificate for every server, reuse it as client certificate.
+	cert := server.TLS.Certificates[0]
+	keyDER, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tlsConfig := TLSClientConfig{
+		CAData:   caData,
+		CertData: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}),
+		KeyData:  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+	}
+
+	if err := getDemo(&Config{Host: server.URL, TLSClientConfig: TLSClientConfig{CAData: caData}}); err == nil {
+		t.Error("expected the handshake to fail without a client certificate")
+	}
+	if err := getDemo(&Config{Host: server.URL, TLSClientConfig: tlsConfig}); err != nil {
+		t.Errorf("unexpected error with a client certificate: %v", err)
+	}
+}
+
+func TestRESTClientForDialAndWrapTransport(t *testing.T) {
+	server, caData := newTLSTestServer(t, false)
+	defer server.Close()
+
+	dialed, wrapped := false, false
+	config := &Config{
+		// The dialer routes the fake host to the test server.
+		Host:            "https://harbor.example.com",
+		TLSClientConfig: TLSClientConfig{CAData: caData, ServerName: "example.com"},
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			dialed = true
+			return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+		},
+	}
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			wrapped = true
+			return rt.RoundTrip(req)
+		})
+	})
+	if err := getDemo(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !dialed || !wrapped {
+		t.Errorf("expected the dialer and the wrapper to be used: dialed=%v wrapped=%v", dialed, wrapped)
+	}
+}
+
+func TestRESTClientForCustomTransport(t *testing.T) {
+	called := false
+	config := &Config{
+		Host: "harbor.example.com",
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			called = true
+			if req.URL.String() != "http://harbor.example.com/api/v2.0/projects/demo" {
+				t.Errorf("unexpected url %s", req.URL)
+			}
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader("{}"))}, nil
+		}),
+	}
+	client, err := RESTClientFor(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Get().Resource("projects").Name("demo").Do().Error(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !called {
+		t.Error("expected the custom transport to be used")
+	}
+
+	config.Insecure = true
+	if _, err := RESTClientFor(config); err == nil {
+		t.Error("expected a custom transport with TLS options to be rejected")
+	}
+}
@@ -30,6 +30,8 @@ type RateLimiter interface {
 	TryAccept() bool
 	// Accept returns once a token becomes available.
 	Accept()
+	// Wait returns nil if a token is taken before the Context is done.
+	Wait(ctx context.Context) error
 	// Stop stops the rate limiter, subsequent calls to CanAccept will return false
 	Stop()
 	// QPS returns QPS of this rate limiter
@@ -92,6 +94,11 @@ func (t *tokenBucketRateLimiter) Accept() {
 	t.clock.Sleep(t.limiter.ReserveN(now, 1).DelayFrom(now))
 }
 
+// Wait blocks until a token becomes available or ctx is done
+func (t *tokenBucketRateLimiter) Wait(ctx context.Context) error {
+	return t.limiter.Wait(ctx)
+}
+
 func (t *tokenBucketRateLimiter) Stop() {
 }
 
@@ -102,7 +109,7 @@ func (t *tokenBucketRateLimiter) QPS() float32 {
 type fakeAlwaysRateLimiter struct{}
 
 func (t *fakeAlwaysRateLimiter) Wait(ctx context.Context) error {
-	panic("implement me")
+	return nil
 }
 
 func NewFakeAlwaysRateLimiter() RateLimiter {
@@ -143,6 +150,20 @@ func (t *fakeNeverRateLimiter) Accept() {
 	t.wg.Wait()
 }
 
+func (t *fakeNeverRateLimiter) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (t *fakeNeverRateLimiter) QPS() float32 {
 	return 1
 }
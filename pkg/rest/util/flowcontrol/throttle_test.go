@@ -17,6 +17,7 @@ limitations under the License.
 package flowcontrol
 
 import (
+	"context"
 	"sync"
 	"testing"
 	"time"
@@ -115,6 +116,19 @@ func TestThrottle(t *testing.T) {
 	}
 }
 
+func TestWaitHonorsContext(t *testing.T) {
+	r := NewTokenBucketRateLimiter(1, 1)
+	if err := r.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error taking the initial token: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := r.Wait(ctx); err == nil {
+		t.Error("Wait should fail when the next token is not available before the deadline")
+	}
+}
+
 func TestAlwaysFake(t *testing.T) {
 	rl := NewFakeAlwaysRateLimiter()
 	if !rl.TryAccept() {
@@ -151,3 +165,14 @@ func TestNeverFake(t *testing.T) {
 		t.Error("Stop should make Accept unblock in NeverFake.")
 	}
 }
+
+func TestNeverFakeWait(t *testing.T) {
+	rl := NewFakeNeverRateLimiter()
+	defer rl.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := rl.Wait(ctx); err != context.Canceled {
+		t.Errorf("Wait in NeverFake should return the context error, got %v", err)
+	}
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package retention
+
+import (
+	"context"
+	"io"
+	"strconv"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
+)
+
+// RetentionInterface manages the tag retention policies of projects and follows their executions.
+type RetentionInterface interface {
+	Metadata() (result *model.RetentionMetadata, err error)
+	Get(id int64) (result *model.RetentionPolicy, err error)
+	ForProject(project string) (result *model.RetentionPolicy, err error)
+	Create(policy *model.RetentionPolicy) (id int64, err error)
+	Update(id int64, policy *model.RetentionPolicy) (err error)
+	Delete(id int64) (err error)
+	StartExecution(id int64, dryRun bool) (executionID int64, err error)
+	StopExecution(id, executionID int64) (err error)
+	ListExecutions(id int64, query *model.Query) (results *[]model.RetentionExecution, err error)
+	ListExecutionsPager(ctx context.Context, id int64, query *model.Query) *rest2.Pager[model.RetentionExecution]
+	ListTasks(id, executionID int64, query *model.Query) (results *[]model.RetentionTask, err error)
+	ListTasksPager(ctx context.Context, id, executionID int64, query *model.Query) *rest2.Pager[model.RetentionTask]
+	TaskLog(id, executionID, taskID int64) (log io.ReadCloser, err error)
+	MetadataContext(ctx context.Context) (result *model.RetentionMetadata, err error)
+	GetContext(ctx context.Context, id int64) (result *model.RetentionPolicy, err error)
+	ForProjectContext(ctx context.Context, project string) (result *model.RetentionPolicy, err error)
+	CreateContext(ctx context.Context, policy *model.RetentionPolicy) (id int64, err error)
+	UpdateContext(ctx context.Context, id int64, policy *model.RetentionPolicy) (err error)
+	DeleteContext(ctx context.Context, id int64) (err error)
+	StartExecutionContext(ctx context.Context, id int64, dryRun bool) (executionID int64, err error)
+	StopExecutionContext(ctx context.Context, id, executionID int64) (err error)
+	ListExecutionsContext(ctx context.Context, id int64, query *model.Query) (results *[]model.RetentionExecution, err error)
+	ListTasksContext(ctx context.Context, id, executionID int64, query *model.Query) (results *[]model.RetentionTask, err error)
+	TaskLogContext(ctx context.Context, id, executionID, taskID int64) (log io.ReadCloser, err error)
+}
+
+var _ RetentionInterface = &RetentionClient{}
+
+type RetentionClient struct {
+	restClient rest2.Interface
+}
+
+func NewRetentionClient(restClient *rest2.Config) (*RetentionClient, error) {
+	client, err := rest2.RESTClientFor(restClient)
+	if err != nil {
+		return nil, err
+	}
+	return &RetentionClient{restClient: client}, nil
+}
+
+// executions points req at /retentions/{id}/executions.
+func executions(req *rest2.Request, id int64) *rest2.Request {
+	return req.Resource("retentions").
+		Name(strconv.FormatInt(id, 10)).
+		Suffix("executions")
+}
+
+// Metadata describes the rule templates and selectors the policies may use.
+func (r *RetentionClient) Metadata() (result *model.RetentionMetadata, err error) {
+	return r.MetadataContext(context.Background())
+}
+
+// MetadataContext is like Metadata but binds the request to ctx.
+func (r *RetentionClient) MetadataContext(ctx context.Context) (result *model.RetentionMetadata, err error) {
+	result = &model.RetentionMetadata{}
+	err = r.restClient.Get().
+		Context(ctx).
+		Resource("retentions").
+		Name("metadatas").
+		Do().
+		Into(result)
+	return
+}
+
+func (r *RetentionClient) Get(id int64) (result *model.RetentionPolicy, err error) {
+	return r.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (r *RetentionClient) GetContext(ctx context.Context, id int64) (result *model.RetentionPolicy, err error) {
+	result = &model.RetentionPolicy{}
+	err = r.restClient.Get().
+		Context(ctx).
+		Resource("retentions").
+		Name(strconv.FormatInt(id, 10)).
+		Do().
+		Into(result)
+	return
+}
+
+// ForProject returns the retention policy of the project called project, following
+// its "retention_id" metadata. It returns nil if the project has no policy.
+func (r *RetentionClient) ForProject(project string) (result *model.RetentionPolicy, err error) {
+	return r.ForProjectContext(context.Background(), project)
+}
+
+// ForProjectContext is like ForProject but binds the requests to ctx.
+func (r *RetentionClient) ForProjectContext(ctx context.Context, project string) (result *model.RetentionPolicy, err error) {
+	metadata := model.ProjectMetadata{}
+	err = r.restClient.Get().
+		Context(ctx).
+		ProjectByName(project).
+		Suffix("metadatas", model.ProMetaRetentionID).
+		Do().
+		Into(&metadata)
+	if err != nil {
+		return nil, err
+	}
+	id, ok := metadata.RetentionID()
+	if !ok {
+		return nil, nil
+	}
+	return r.GetContext(ctx, id)
+}
+
+// Create creates a retention policy and returns its ID, see model.NewRetentionPolicy.
+// A project has at most one policy.
+func (r *RetentionClient) Create(policy *model.RetentionPolicy) (id int64, err error) {
+	return r.CreateContext(context.Background(), policy)
+}
+
+// CreateContext is like Create but binds the request to ctx.
+func (r *RetentionClient) CreateContext(ctx context.Context, policy *model.RetentionPolicy) (id int64, err error) {
+	return r.restClient.Post().
+		Context(ctx).
+		Resource("retentions").
+		Body(policy).
+		Do().
+		CreatedID()
+}
+
+// Update replaces the rules and trigger of the retention policy id.
+func (r *RetentionClient) Update(id int64, policy *model.RetentionPolicy) (err error) {
+	return r.UpdateContext(context.Background(), id, policy)
+}
+
+// UpdateContext is like Update but binds the request to ctx.
+func (r *RetentionClient) UpdateContext(ctx context.Context, id int64, policy *model.RetentionPolicy) (err error) {
+	return r.restClient.Put().
+		Context(ctx).
+		Resource("retentions").
+		Name(strconv.FormatInt(id, 10)).
+		Body(policy).
+		Do().
+		Error()
+}
+
+func (r *RetentionClient) Delete(id int64) (err error) {
+	return r.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext is like Delete but binds the request to ctx.
+func (r *RetentionClient) DeleteContext(ctx context.Context, id int64) (err error) {
+	return r.restClient.Delete().
+		Context(ctx).
+		Resource("retentions").
+		Name(strconv.FormatInt(id, 10)).
+		Do().
+		Error()
+}
+
+// StartExecution runs the retention policy id now and returns the ID of the
+// execution. A dry run only reports, through the tasks, what would be deleted.
+func (r *RetentionClient) StartExecution(id int64, dryRun bool) (executionID int64, err error) {
+	return r.StartExecutionContext(context.Background(), id, dryRun)
+}
+
+// StartExecutionContext is like StartExecution but binds the request to ctx.
+func (r *RetentionClient) StartExecutionContext(ctx context.Context, id int64, dryRun bool) (executionID int64, err error) {
+	return executions(r.restClient.Post().Context(ctx), id).
+		Body(map[string]bool{"dry_run": dryRun}).
+		Do().
+		CreatedID()
+}
+
+// StopExecution stops the execution executionID of the retention policy id.
+func (r *RetentionClient) StopExecution(id, executionID int64) (err error) {
+	return r.StopExecutionContext(context.Background(), id, executionID)
+}
+
+// StopExecutionContext is like StopExecution but binds the request to ctx.
+func (r *RetentionClient) StopExecutionContext(ctx context.Context, id, executionID int64) (err error) {
+	return executions(r.restClient.Patch().Context(ctx), id).
+		Suffix(strconv.FormatInt(executionID, 10)).
+		Body(map[string]string{"action": "stop"}).
+		Do().
+		Error()
+}
+
+// ListExecutions lists the executions of the retention policy id, latest first.
+func (r *RetentionClient) ListExecutions(id int64, query *model.Query) (results *[]model.RetentionExecution, err error) {
+	return r.ListExecutionsContext(context.Background(), id, query)
+}
+
+// ListExecutionsContext is like ListExecutions but binds the request to ctx.
+func (r *RetentionClient) ListExecutionsContext(ctx context.Context, id int64, query *model.Query) (results *[]model.RetentionExecution, err error) {
+	results = &[]model.RetentionExecution{}
+	err = executions(r.restClient.List().Context(ctx), id).
+		Params(*query).
+		Do().
+		Into(results)
+	return
+}
+
+// ListExecutionsPager returns a Pager walking every execution of the retention policy id.
+func (r *RetentionClient) ListExecutionsPager(ctx context.Context, id int64, query *model.Query) *rest2.Pager[model.RetentionExecution] {
+	return rest2.NewPager[model.RetentionExecution](ctx, func() *rest2.Request {
+		return executions(r.restClient.List(), id).
+			Params(*query)
+	})
+}
+
+// ListTasks lists the tasks of the execution executionID of the retention policy
+// id, one per repository.
+func (r *RetentionClient) ListTasks(id, executionID int64, query *model.Query) (results *[]model.RetentionTask, err error) {
+	return r.ListTasksContext(context.Background(), id, executionID, query)
+}
+
+// ListTasksContext is like ListTasks but binds the request to ctx.
+func (r *RetentionClient) ListTasksContext(ctx context.Context, id, executionID int64, query *model.Query) (results *[]model.RetentionTask, err error) {
+	results = &[]model.RetentionTask{}
+	err = executions(r.restClient.List().Context(ctx), id).
+		Suffix(strconv.FormatInt(executionID, 10), "tasks").
+		Params(*query).
+		Do().
+		Into(results)
+	return
+}
+
+// ListTasksPager returns a Pager walking every task of the execution executionID
+// of the retention policy id.
+func (r *RetentionClient) ListTasksPager(ctx context.Context, id, executionID int64, query *model.Query) *rest2.Pager[model.RetentionTask] {
+	return rest2.NewPager[model.RetentionTask](ctx, func() *rest2.Request {
+		return executions(r.restClient.List(), id).
+			Suffix(strconv.FormatInt(executionID, 10), "tasks").
+			Params(*query)
+	})
+}
+
+// TaskLog streams the log of the task taskID, listing the retained and deleted
+// artifacts of its repository. The caller must close the returned log.
+func (r *RetentionClient) TaskLog(id, executionID, taskID int64) (log io.ReadCloser, err error) {
+	return r.TaskLogContext(context.Background(), id, executionID, taskID)
+}
+
+// TaskLogContext is like TaskLog but binds the request to ctx.
+func (r *RetentionClient) TaskLogContext(ctx context.Context, id, executionID, taskID int64) (log io.ReadCloser, err error) {
+	return executions(r.restClient.Get().Context(ctx), id).
+		Suffix(strconv.FormatInt(executionID, 10), "tasks", strconv.FormatInt(taskID, 10)).
+		Stream()
+}
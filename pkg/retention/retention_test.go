@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package retention
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
+	"github.com/TimeBye/go-harbor/pkg/rest/resttest"
+)
+
+func newTestClient(t *testing.T) (*RetentionClient, *resttest.Server) {
+	server := resttest.NewServer(t)
+	client, err := NewRetentionClient(server.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server
+}
+
+func TestRetentionCreate(t *testing.T) {
+	client, server := newTestClient(t)
+	server.ReplyCreated(http.MethodPost, "/retentions", "8")
+
+	id, err := client.Create(model.NewRetentionPolicy(1, model.RetainLatestPushed(10)))
+	if err != nil || id != 8 {
+		t.Fatalf("unexpected id %d: %v", id, err)
+	}
+	policy := &model.RetentionPolicy{}
+	server.Last().Decode(t, policy)
+	if policy.Scope == nil || policy.Scope.Reference != 1 || len(policy.Rules) != 1 {
+		t.Errorf("unexpected body %s", server.Last().Body)
+	}
+}
+
+func TestRetentionForProject(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodGet, "/projects/library/metadatas/retention_id", http.StatusOK, `{"retention_id":"8"}`)
+	server.Reply(http.MethodGet, "/retentions/8", http.StatusOK, `{"id":8,"algorithm":"or","rules":[],"scope":{"level":"project","ref":1}}`)
+
+	policy, err := client.ForProject("library")
+	if err != nil || policy == nil || policy.ID != 8 {
+		t.Fatalf("unexpected policy %+v: %v", policy, err)
+	}
+	lookup := server.Requests()[0]
+	if lookup.Header.Get(rest2.HeaderIsResourceName) != "true" {
+		t.Errorf("expected the project to be referenced by name")
+	}
+
+	server.Reply(http.MethodGet, "/projects/library/metadatas/retention_id", http.StatusOK, `{}`)
+	policy, err = client.ForProject("library")
+	if err != nil || policy != nil {
+		t.Errorf("expected no policy, got %+v: %v", policy, err)
+	}
+}
+
+func TestRetentionExecutions(t *testing.T) {
+	client, server := newTestClient(t)
+	server.ReplyCreated(http.MethodPost, "/retentions/8/executions", "41")
+	server.Reply(http.MethodPatch, "/retentions/8/executions/41", http.StatusOK, "")
+
+	id, err := client.StartExecution(8, true)
+	if err != nil || id != 41 {
+		t.Fatalf("unexpected id %d: %v", id, err)
+	}
+	if body := string(server.Last().Body); body != `{"dry_run":true}` {
+		t.Errorf("unexpected body %s", body)
+	}
+
+	if err := client.StopExecution(8, 41); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"action":"stop"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+}
+
+func TestRetentionListTasksPager(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Handle(http.MethodGet, "/retentions/8/executions/41/tasks", func(w http.ResponseWriter, req *http.Request) {
+		page := req.URL.Query().Get("page")
+		if page == "1" {
+			w.Header().Set("Link", `</api/v2.0/retentions/8/executions/41/tasks?page=2&page_size=1>; rel="next"`)
+		}
+		fmt.Fprintf(w, `[{"id":%s,"execution_id":41,"repository":"nginx","total":5,"retained":3}]`, page)
+	})
+
+	tasks, err := client.ListTasksPager(context.Background(), 8, 41, &model.Query{PageSize: 1}).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 2 || tasks[1].ID != 2 || tasks[0].Retained != 3 {
+		t.Errorf("unexpected tasks %+v", tasks)
+	}
+	if requests := server.Requests(); len(requests) != 2 {
+		t.Errorf("expected 2 pages, got %d requests", len(requests))
+	}
+}
+
+func TestRetentionTaskLog(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Handle(http.MethodGet, "/retentions/8/executions/41/tasks/2", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, "Digest  Tag     Kind   Labels  PushedTime  PulledTime  CreatedTime  Retention\n")
+	})
+
+	log, err := client.TaskLog(8, 41, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer log.Close()
+	content, err := ioutil.ReadAll(log)
+	if err != nil || len(content) == 0 {
+		t.Errorf("unexpected log %q: %v", content, err)
+	}
+}
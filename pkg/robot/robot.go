@@ -0,0 +1,250 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package robot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
+)
+
+// RobotsInterface manages system and project robot accounts. Project robots are
+// listed with a query such as "Level=project,ProjectID=1".
+type RobotsInterface interface {
+	Get(id int64) (result *model.Robot, err error)
+	List(query *model.Query) (results *[]model.Robot, err error)
+	ListPager(ctx context.Context, query *model.Query) *rest2.Pager[model.Robot]
+	ListAll(query *model.Query) (results *[]model.Robot, err error)
+	Create(robot *model.RobotCreate) (result *model.RobotCreated, err error)
+	Update(robot *model.Robot) (err error)
+	Delete(id int64) (err error)
+	RefreshSecret(id int64, secret string) (newSecret string, err error)
+	SetDisabled(id int64, disabled bool) (err error)
+	SetDuration(id int64, days int64) (err error)
+	Rotate(id int64) (result *model.RobotCredential, err error)
+	GetContext(ctx context.Context, id int64) (result *model.Robot, err error)
+	ListContext(ctx context.Context, query *model.Query) (results *[]model.Robot, err error)
+	ListAllContext(ctx context.Context, query *model.Query) (results *[]model.Robot, err error)
+	CreateContext(ctx context.Context, robot *model.RobotCreate) (result *model.RobotCreated, err error)
+	UpdateContext(ctx context.Context, robot *model.Robot) (err error)
+	DeleteContext(ctx context.Context, id int64) (err error)
+	RefreshSecretContext(ctx context.Context, id int64, secret string) (newSecret string, err error)
+	SetDisabledContext(ctx context.Context, id int64, disabled bool) (err error)
+	SetDurationContext(ctx context.Context, id int64, days int64) (err error)
+	RotateContext(ctx context.Context, id int64) (result *model.RobotCredential, err error)
+}
+
+var _ RobotsInterface = &RobotsClient{}
+
+type RobotsClient struct {
+	restClient rest2.Interface
+}
+
+func NewRobotsClient(restClient *rest2.Config) (*RobotsClient, error) {
+	client, err := rest2.RESTClientFor(restClient)
+	if err != nil {
+		return nil, err
+	}
+	return &RobotsClient{restClient: client}, nil
+}
+
+func (r *RobotsClient) Get(id int64) (result *model.Robot, err error) {
+	return r.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (r *RobotsClient) GetContext(ctx context.Context, id int64) (result *model.Robot, err error) {
+	result = &model.Robot{}
+	err = r.restClient.Get().
+		Context(ctx).
+		Resource("robots").
+		Name(strconv.FormatInt(id, 10)).
+		Do().
+		Into(result)
+	return
+}
+
+func (r *RobotsClient) List(query *model.Query) (results *[]model.Robot, err error) {
+	return r.ListContext(context.Background(), query)
+}
+
+// ListContext is like List but binds the request to ctx.
+func (r *RobotsClient) ListContext(ctx context.Context, query *model.Query) (results *[]model.Robot, err error) {
+	results = &[]model.Robot{}
+	err = r.restClient.List().
+		Context(ctx).
+		Resource("robots").
+		Params(*query).
+		Do().
+		Into(results)
+	return
+}
+
+// ListPager returns a Pager walking every robot matching query.
+func (r *RobotsClient) ListPager(ctx context.Context, query *model.Query) *rest2.Pager[model.Robot] {
+	return rest2.NewPager[model.Robot](ctx, func() *rest2.Request {
+		return r.restClient.List().
+			Resource("robots").
+			Params(*query)
+	})
+}
+
+// ListAll returns every robot matching query, walking all pages.
+func (r *RobotsClient) ListAll(query *model.Query) (results *[]model.Robot, err error) {
+	return r.ListAllContext(context.Background(), query)
+}
+
+// ListAllContext is like ListAll but binds the requests to ctx.
+func (r *RobotsClient) ListAllContext(ctx context.Context, query *model.Query) (results *[]model.Robot, err error) {
+	items, err := r.ListPager(ctx, query).All()
+	return &items, err
+}
+
+// Create creates a robot account. The secret of the robot is only returned here
+// and by RefreshSecret.
+func (r *RobotsClient) Create(robot *model.RobotCreate) (result *model.RobotCreated, err error) {
+	return r.CreateContext(context.Background(), robot)
+}
+
+// CreateContext is like Create but binds the request to ctx.
+func (r *RobotsClient) CreateContext(ctx context.Context, robot *model.RobotCreate) (result *model.RobotCreated, err error) {
+	result = &model.RobotCreated{}
+	err = r.restClient.Post().
+		Context(ctx).
+		Resource("robots").
+		Body(robot).
+		Do().
+		Into(result)
+	return
+}
+
+// Update replaces the description, permissions, duration and disabled flag of robot.
+// Harbor expects the whole robot, as returned by Get.
+func (r *RobotsClient) Update(robot *model.Robot) (err error) {
+	return r.UpdateContext(context.Background(), robot)
+}
+
+// UpdateContext is like Update but binds the request to ctx.
+func (r *RobotsClient) UpdateContext(ctx context.Context, robot *model.Robot) (err error) {
+	return r.restClient.Put().
+		Context(ctx).
+		Resource("robots").
+		Name(strconv.FormatInt(robot.ID, 10)).
+		Body(robot).
+		Do().
+		Error()
+}
+
+func (r *RobotsClient) Delete(id int64) (err error) {
+	return r.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext is like Delete but binds the request to ctx.
+func (r *RobotsClient) DeleteContext(ctx context.Context, id int64) (err error) {
+	return r.restClient.Delete().
+		Context(ctx).
+		Resource("robots").
+		Name(strconv.FormatInt(id, 10)).
+		Do().
+		Error()
+}
+
+// RefreshSecret replaces the secret of the robot id with secret, or with a secret
+// generated by Harbor if secret is empty, and returns the new secret. It fails
+// rather than returning an empty secret.
+func (r *RobotsClient) RefreshSecret(id int64, secret string) (newSecret string, err error) {
+	return r.RefreshSecretContext(context.Background(), id, secret)
+}
+
+// RefreshSecretContext is like RefreshSecret but binds the request to ctx.
+func (r *RobotsClient) RefreshSecretContext(ctx context.Context, id int64, secret string) (newSecret string, err error) {
+	result := &model.RobotSec{}
+	err = r.restClient.Patch().
+		Context(ctx).
+		Resource("robots").
+		Name(strconv.FormatInt(id, 10)).
+		Body(&model.RobotSec{Secret: secret}).
+		Do().
+		Into(result)
+	if err != nil {
+		return "", err
+	}
+	if len(result.Secret) > 0 {
+		return result.Secret, nil
+	}
+	if len(secret) == 0 {
+		return "", fmt.Errorf("harbor returned no generated secret for robot %d", id)
+	}
+	// Harbor only echoes generated secrets.
+	return secret, nil
+}
+
+// SetDisabled disables or enables the robot id.
+func (r *RobotsClient) SetDisabled(id int64, disabled bool) (err error) {
+	return r.SetDisabledContext(context.Background(), id, disabled)
+}
+
+// SetDisabledContext is like SetDisabled but binds the requests to ctx.
+func (r *RobotsClient) SetDisabledContext(ctx context.Context, id int64, disabled bool) (err error) {
+	return r.modify(ctx, id, func(robot *model.Robot) {
+		robot.Disable = disabled
+	})
+}
+
+// SetDuration changes the lifetime of the robot id to days from its creation,
+// model.RobotNeverExpires for a robot that never expires.
+func (r *RobotsClient) SetDuration(id int64, days int64) (err error) {
+	return r.SetDurationContext(context.Background(), id, days)
+}
+
+// SetDurationContext is like SetDuration but binds the requests to ctx.
+func (r *RobotsClient) SetDurationContext(ctx context.Context, id int64, days int64) (err error) {
+	return r.modify(ctx, id, func(robot *model.Robot) {
+		robot.Duration = days
+	})
+}
+
+// modify reads the robot id, applies fn to it and writes it back.
+func (r *RobotsClient) modify(ctx context.Context, id int64, fn func(robot *model.Robot)) error {
+	robot, err := r.GetContext(ctx, id)
+	if err != nil {
+		return err
+	}
+	fn(robot)
+	return r.UpdateContext(ctx, robot)
+}
+
+// Rotate regenerates the secret of the robot id and returns the credential to
+// authenticate as the robot from now on.
+func (r *RobotsClient) Rotate(id int64) (result *model.RobotCredential, err error) {
+	return r.RotateContext(context.Background(), id)
+}
+
+// RotateContext is like Rotate but binds the requests to ctx.
+func (r *RobotsClient) RotateContext(ctx context.Context, id int64) (result *model.RobotCredential, err error) {
+	robot, err := r.GetContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := r.RefreshSecretContext(ctx, id, "")
+	if err != nil {
+		return nil, err
+	}
+	return &model.RobotCredential{Name: robot.Name, Secret: secret, ExpiresAt: robot.ExpiresAt}, nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package robot
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/TimeBye/go-harbor/pkg/rest/resttest"
+)
+
+func newTestClient(t *testing.T) (*RobotsClient, *resttest.Server) {
+	server := resttest.NewServer(t)
+	client, err := NewRobotsClient(server.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server
+}
+
+func TestRobotRefreshSecret(t *testing.T) {
+	client, server := newTestClient(t)
+
+	// Harbor generates and echoes the secret when none is given.
+	server.Reply(http.MethodPatch, "/robots/3", http.StatusOK, `{"secret":"Generated1"}`)
+	secret, err := client.RefreshSecret(3, "")
+	if err != nil || secret != "Generated1" {
+		t.Errorf("unexpected secret %q: %v", secret, err)
+	}
+	if body := string(server.Last().Body); body != `{"secret":""}` {
+		t.Errorf("unexpected body %s", body)
+	}
+
+	// Harbor does not echo a secret set by the client.
+	server.Reply(http.MethodPatch, "/robots/3", http.StatusOK, "")
+	secret, err = client.RefreshSecret(3, "Chosen1234")
+	if err != nil || secret != "Chosen1234" {
+		t.Errorf("unexpected secret %q: %v", secret, err)
+	}
+	if body := string(server.Last().Body); body != `{"secret":"Chosen1234"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+
+	// Nor should it leave a generated secret out, which would lock the robot out.
+	if secret, err := client.RefreshSecret(3, ""); err == nil || secret != "" {
+		t.Errorf("expected an error for a missing generated secret, got %q", secret)
+	}
+}
+
+func TestRobotRotate(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodGet, "/robots/3", http.StatusOK, `{"id":3,"name":"robot$library+ci","level":"project","duration":30,"expires_at":1712534400}`)
+	server.Reply(http.MethodPatch, "/robots/3", http.StatusOK, `{"secret":"Generated1"}`)
+
+	credential, err := client.Rotate(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if credential.Name != "robot$library+ci" || credential.Secret != "Generated1" || credential.ExpiresAt != 1712534400 {
+		t.Errorf("unexpected credential %+v", credential)
+	}
+
+	server.Reply(http.MethodPatch, "/robots/3", http.StatusOK, `{}`)
+	if credential, err := client.Rotate(3); err == nil || credential != nil {
+		t.Errorf("expected an error for a missing secret, got %+v", credential)
+	}
+}
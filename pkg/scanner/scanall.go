@@ -0,0 +1,164 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package scanner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
+)
+
+// ScanAllInterface runs and schedules the scan of every artifact of Harbor.
+type ScanAllInterface interface {
+	Trigger() (err error)
+	Stop() (err error)
+	Schedule() (result *model.Schedule, err error)
+	SetSchedule(schedule *model.ScheduleObj) (err error)
+	Metrics() (result *model.ScanAllMetrics, err error)
+	ScheduleMetrics() (result *model.ScanAllMetrics, err error)
+	TriggerContext(ctx context.Context) (err error)
+	StopContext(ctx context.Context) (err error)
+	ScheduleContext(ctx context.Context) (result *model.Schedule, err error)
+	SetScheduleContext(ctx context.Context, schedule *model.ScheduleObj) (err error)
+	MetricsContext(ctx context.Context) (result *model.ScanAllMetrics, err error)
+	ScheduleMetricsContext(ctx context.Context) (result *model.ScanAllMetrics, err error)
+}
+
+var _ ScanAllInterface = &ScanAllClient{}
+
+type ScanAllClient struct {
+	restClient rest2.Interface
+}
+
+func NewScanAllClient(restClient *rest2.Config) (*ScanAllClient, error) {
+	client, err := rest2.RESTClientFor(restClient)
+	if err != nil {
+		return nil, err
+	}
+	return &ScanAllClient{restClient: client}, nil
+}
+
+// scanAll points req at /system/scanAll.
+func scanAll(req *rest2.Request) *rest2.Request {
+	return req.Resource("system").Name("scanAll")
+}
+
+// Trigger starts scanning every artifact now.
+func (s *ScanAllClient) Trigger() (err error) {
+	return s.TriggerContext(context.Background())
+}
+
+// TriggerContext is like Trigger but binds the request to ctx.
+func (s *ScanAllClient) TriggerContext(ctx context.Context) (err error) {
+	return scanAll(s.restClient.Post().Context(ctx)).
+		Suffix("schedule").
+		Body(&model.Schedule{Schedule: &model.ScheduleObj{Type: model.ScheduleTypeManual}}).
+		Do().
+		Error()
+}
+
+// Stop stops the running scan all.
+func (s *ScanAllClient) Stop() (err error) {
+	return s.StopContext(context.Background())
+}
+
+// StopContext is like Stop but binds the request to ctx.
+func (s *ScanAllClient) StopContext(ctx context.Context) (err error) {
+	return scanAll(s.restClient.Post().Context(ctx)).
+		Suffix("stop").
+		Do().
+		Error()
+}
+
+// Schedule returns the schedule of scan all, with an ID of 0 if it was never scheduled.
+func (s *ScanAllClient) Schedule() (result *model.Schedule, err error) {
+	return s.ScheduleContext(context.Background())
+}
+
+// ScheduleContext is like Schedule but binds the request to ctx.
+func (s *ScanAllClient) ScheduleContext(ctx context.Context) (result *model.Schedule, err error) {
+	result = &model.Schedule{}
+	err = scanAll(s.restClient.Get().Context(ctx)).
+		Suffix("schedule").
+		Do().
+		Into(result)
+	return
+}
+
+// SetSchedule schedules scan all, e.g. with model.CronSchedule, or unschedules it
+// with model.NoSchedule. The schedule is created or updated as needed, Manual
+// schedules are rejected: use Trigger.
+func (s *ScanAllClient) SetSchedule(schedule *model.ScheduleObj) (err error) {
+	return s.SetScheduleContext(context.Background(), schedule)
+}
+
+// SetScheduleContext is like SetSchedule but binds the requests to ctx.
+func (s *ScanAllClient) SetScheduleContext(ctx context.Context, schedule *model.ScheduleObj) (err error) {
+	if schedule.Type == model.ScheduleTypeManual {
+		return fmt.Errorf("a %s schedule cannot be set, use Trigger to run scan all now", model.ScheduleTypeManual)
+	}
+	existing, err := s.ScheduleContext(ctx)
+	if err != nil {
+		return err
+	}
+	req := s.restClient.Post()
+	if existing.ID != 0 {
+		req = s.restClient.Put()
+	}
+	return scanAll(req.Context(ctx)).
+		Suffix("schedule").
+		Body(&model.Schedule{Schedule: schedule}).
+		Do().
+		Error()
+}
+
+// Metrics reports the progress of the latest scan all run.
+func (s *ScanAllClient) Metrics() (result *model.ScanAllMetrics, err error) {
+	return s.MetricsContext(context.Background())
+}
+
+// MetricsContext is like Metrics but binds the request to ctx.
+func (s *ScanAllClient) MetricsContext(ctx context.Context) (result *model.ScanAllMetrics, err error) {
+	result = &model.ScanAllMetrics{}
+	err = s.restClient.Get().
+		Context(ctx).
+		Resource("scans").
+		Name("all").
+		Suffix("metrics").
+		Do().
+		Into(result)
+	return
+}
+
+// ScheduleMetrics reports the progress of the latest scheduled scan all run.
+func (s *ScanAllClient) ScheduleMetrics() (result *model.ScanAllMetrics, err error) {
+	return s.ScheduleMetricsContext(context.Background())
+}
+
+// ScheduleMetricsContext is like ScheduleMetrics but binds the request to ctx.
+func (s *ScanAllClient) ScheduleMetricsContext(ctx context.Context) (result *model.ScanAllMetrics, err error) {
+	result = &model.ScanAllMetrics{}
+	err = s.restClient.Get().
+		Context(ctx).
+		Resource("scans").
+		Name("schedule").
+		Suffix("metrics").
+		Do().
+		Into(result)
+	return
+}
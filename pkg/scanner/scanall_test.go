@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package scanner
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	"github.com/TimeBye/go-harbor/pkg/rest/resttest"
+)
+
+func newTestScanAll(t *testing.T) (*ScanAllClient, *resttest.Server) {
+	server := resttest.NewServer(t)
+	client, err := NewScanAllClient(server.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server
+}
+
+func TestScanAllTrigger(t *testing.T) {
+	client, server := newTestScanAll(t)
+	server.ReplyCreated(http.MethodPost, "/system/scanAll/schedule", "1")
+	server.Reply(http.MethodPost, "/system/scanAll/stop", http.StatusAccepted, "")
+
+	if err := client.Trigger(); err != nil {
+		t.Fatal(err)
+	}
+	schedule := &model.Schedule{}
+	server.Last().Decode(t, schedule)
+	if schedule.Schedule == nil || schedule.Schedule.Type != model.ScheduleTypeManual {
+		t.Errorf("unexpected body %s", server.Last().Body)
+	}
+	if err := client.Stop(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanAllSetSchedule(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		existing string
+		method   string
+	}{
+		{name: "never scheduled", existing: `{"schedule":null}`, method: http.MethodPost},
+		{name: "scheduled", existing: `{"id":4,"schedule":{"type":"Daily","cron":"0 0 0 * * *"}}`, method: http.MethodPut},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			client, server := newTestScanAll(t)
+			server.Reply(http.MethodGet, "/system/scanAll/schedule", http.StatusOK, c.existing)
+			server.Reply(c.method, "/system/scanAll/schedule", http.StatusOK, "")
+
+			if err := client.SetSchedule(model.CronSchedule("0 0 2 * * *")); err != nil {
+				t.Fatal(err)
+			}
+			last := server.Last()
+			if last.Method != c.method {
+				t.Errorf("expected %s, got %s", c.method, last.Method)
+			}
+			schedule := &model.Schedule{}
+			last.Decode(t, schedule)
+			if schedule.Schedule == nil || schedule.Schedule.Type != model.ScheduleTypeCustom || schedule.Schedule.Cron != "0 0 2 * * *" {
+				t.Errorf("unexpected body %s", last.Body)
+			}
+		})
+	}
+}
+
+func TestScanAllSetScheduleRejectsManual(t *testing.T) {
+	client, server := newTestScanAll(t)
+	server.Reply(http.MethodGet, "/system/scanAll/schedule", http.StatusOK, `{"id":4,"schedule":{"type":"Daily","cron":"0 0 0 * * *"}}`)
+
+	if err := client.SetSchedule(&model.ScheduleObj{Type: model.ScheduleTypeManual}); err == nil {
+		t.Fatal("expected a Manual schedule to be rejected")
+	}
+	if requests := server.Requests(); len(requests) != 0 {
+		t.Errorf("expected no request, got %d", len(requests))
+	}
+}
+
+func TestScanAllMetrics(t *testing.T) {
+	client, server := newTestScanAll(t)
+	server.Reply(http.MethodGet, "/scans/all/metrics", http.StatusOK, `{"total":10,"completed":4,"metrics":{"Success":3,"Error":1,"Running":6},"ongoing":true,"trigger":"Manual"}`)
+	server.Reply(http.MethodGet, "/scans/schedule/metrics", http.StatusOK, `{"total":0,"completed":0,"ongoing":false,"trigger":"Schedule"}`)
+
+	metrics, err := client.Metrics()
+	if err != nil || metrics.Total != 10 || metrics.Metrics["Running"] != 6 || !metrics.Ongoing {
+		t.Errorf("unexpected metrics %+v: %v", metrics, err)
+	}
+	metrics, err = client.ScheduleMetrics()
+	if err != nil || metrics.Trigger != "Schedule" || metrics.Ongoing {
+		t.Errorf("unexpected metrics %+v: %v", metrics, err)
+	}
+}
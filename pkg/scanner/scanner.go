@@ -0,0 +1,198 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
+)
+
+// ScannersInterface manages the scanner adapters registered in Harbor. The scanner of
+// a project is read and set through the projects client.
+type ScannersInterface interface {
+	Get(uuid string) (result *model.ScannerRegistration, err error)
+	List(query *model.Query) (results *[]model.ScannerRegistration, err error)
+	Create(scanner *model.ScannerRegistrationReq) (uuid string, err error)
+	Update(uuid string, scanner *model.ScannerRegistrationReq) (err error)
+	Delete(uuid string) (err error)
+	SetDefault(uuid string) (err error)
+	Ping(scanner *model.ScannerRegistrationReq) (err error)
+	Metadata(uuid string) (result *model.ScannerAdapterMetadata, err error)
+	GetContext(ctx context.Context, uuid string) (result *model.ScannerRegistration, err error)
+	ListContext(ctx context.Context, query *model.Query) (results *[]model.ScannerRegistration, err error)
+	CreateContext(ctx context.Context, scanner *model.ScannerRegistrationReq) (uuid string, err error)
+	UpdateContext(ctx context.Context, uuid string, scanner *model.ScannerRegistrationReq) (err error)
+	DeleteContext(ctx context.Context, uuid string) (err error)
+	SetDefaultContext(ctx context.Context, uuid string) (err error)
+	PingContext(ctx context.Context, scanner *model.ScannerRegistrationReq) (err error)
+	MetadataContext(ctx context.Context, uuid string) (result *model.ScannerAdapterMetadata, err error)
+}
+
+var _ ScannersInterface = &ScannersClient{}
+
+type ScannersClient struct {
+	restClient rest2.Interface
+}
+
+func NewScannersClient(restClient *rest2.Config) (*ScannersClient, error) {
+	client, err := rest2.RESTClientFor(restClient)
+	if err != nil {
+		return nil, err
+	}
+	return &ScannersClient{restClient: client}, nil
+}
+
+func (s *ScannersClient) Get(uuid string) (result *model.ScannerRegistration, err error) {
+	return s.GetContext(context.Background(), uuid)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (s *ScannersClient) GetContext(ctx context.Context, uuid string) (result *model.ScannerRegistration, err error) {
+	result = &model.ScannerRegistration{}
+	err = s.restClient.Get().
+		Context(ctx).
+		Resource("scanners").
+		Name(uuid).
+		Do().
+		Into(result)
+	return
+}
+
+func (s *ScannersClient) List(query *model.Query) (results *[]model.ScannerRegistration, err error) {
+	return s.ListContext(context.Background(), query)
+}
+
+// ListContext is like List but binds the request to ctx.
+func (s *ScannersClient) ListContext(ctx context.Context, query *model.Query) (results *[]model.ScannerRegistration, err error) {
+	results = &[]model.ScannerRegistration{}
+	err = s.restClient.List().
+		Context(ctx).
+		Resource("scanners").
+		Params(*query).
+		Do().
+		Into(results)
+	return
+}
+
+// Create registers a scanner adapter and returns its UUID.
+func (s *ScannersClient) Create(scanner *model.ScannerRegistrationReq) (uuid string, err error) {
+	return s.CreateContext(context.Background(), scanner)
+}
+
+// CreateContext is like Create but binds the request to ctx.
+func (s *ScannersClient) CreateContext(ctx context.Context, scanner *model.ScannerRegistrationReq) (uuid string, err error) {
+	result := s.restClient.Post().
+		Context(ctx).
+		Resource("scanners").
+		Body(scanner).
+		Do()
+	if err = result.Error(); err != nil {
+		return "", err
+	}
+	location := result.Location()
+	if len(location) == 0 {
+		return "", fmt.Errorf("the response to the scanner registration has no Location header")
+	}
+	return path.Base(location), nil
+}
+
+// Update replaces the settings of the scanner adapter uuid.
+func (s *ScannersClient) Update(uuid string, scanner *model.ScannerRegistrationReq) (err error) {
+	return s.UpdateContext(context.Background(), uuid, scanner)
+}
+
+// UpdateContext is like Update but binds the request to ctx.
+func (s *ScannersClient) UpdateContext(ctx context.Context, uuid string, scanner *model.ScannerRegistrationReq) (err error) {
+	return s.restClient.Put().
+		Context(ctx).
+		Resource("scanners").
+		Name(uuid).
+		Body(scanner).
+		Do().
+		Error()
+}
+
+// Delete unregisters the scanner adapter uuid.
+func (s *ScannersClient) Delete(uuid string) (err error) {
+	return s.DeleteContext(context.Background(), uuid)
+}
+
+// DeleteContext is like Delete but binds the request to ctx.
+func (s *ScannersClient) DeleteContext(ctx context.Context, uuid string) (err error) {
+	return s.restClient.Delete().
+		Context(ctx).
+		Resource("scanners").
+		Name(uuid).
+		Do().
+		Error()
+}
+
+// SetDefault makes the scanner adapter uuid the system default, used by the
+// projects that have no scanner of their own.
+func (s *ScannersClient) SetDefault(uuid string) (err error) {
+	return s.SetDefaultContext(context.Background(), uuid)
+}
+
+// SetDefaultContext is like SetDefault but binds the request to ctx.
+func (s *ScannersClient) SetDefaultContext(ctx context.Context, uuid string) (err error) {
+	return s.restClient.Patch().
+		Context(ctx).
+		Resource("scanners").
+		Name(uuid).
+		Body(map[string]bool{"is_default": true}).
+		Do().
+		Error()
+}
+
+// Ping checks that Harbor can reach the scanner adapter described by scanner,
+// registered or not.
+func (s *ScannersClient) Ping(scanner *model.ScannerRegistrationReq) (err error) {
+	return s.PingContext(context.Background(), scanner)
+}
+
+// PingContext is like Ping but binds the request to ctx.
+func (s *ScannersClient) PingContext(ctx context.Context, scanner *model.ScannerRegistrationReq) (err error) {
+	return s.restClient.Post().
+		Context(ctx).
+		Resource("scanners").
+		Name("ping").
+		Body(scanner).
+		Do().
+		Error()
+}
+
+// Metadata returns the capabilities of the scanner adapter uuid, including the
+// mime types it consumes and produces.
+func (s *ScannersClient) Metadata(uuid string) (result *model.ScannerAdapterMetadata, err error) {
+	return s.MetadataContext(context.Background(), uuid)
+}
+
+// MetadataContext is like Metadata but binds the request to ctx.
+func (s *ScannersClient) MetadataContext(ctx context.Context, uuid string) (result *model.ScannerAdapterMetadata, err error) {
+	result = &model.ScannerAdapterMetadata{}
+	err = s.restClient.Get().
+		Context(ctx).
+		Resource("scanners").
+		Name(uuid).
+		Suffix("metadata").
+		Do().
+		Into(result)
+	return
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package scanner
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	"github.com/TimeBye/go-harbor/pkg/rest/resttest"
+)
+
+func newTestScanners(t *testing.T) (*ScannersClient, *resttest.Server) {
+	server := resttest.NewServer(t)
+	client, err := NewScannersClient(server.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server
+}
+
+func TestScannerCreate(t *testing.T) {
+	client, server := newTestScanners(t)
+	uuid := "a8b0a1c8-9a52-11ee-b9d1-0242ac120002"
+	server.ReplyCreated(http.MethodPost, "/scanners", uuid)
+
+	got, err := client.Create(&model.ScannerRegistrationReq{Name: "Trivy", URL: "http://trivy-adapter:8080", Auth: "Bearer", AccessCredential: "t0ken"})
+	if err != nil || got != uuid {
+		t.Fatalf("unexpected uuid %q: %v", got, err)
+	}
+	want := `{"name":"Trivy","url":"http://trivy-adapter:8080","auth":"Bearer","access_credential":"t0ken","skip_certVerify":false,"use_internal_addr":false,"disabled":false}`
+	if body := string(server.Last().Body); body != want {
+		t.Errorf("unexpected body %s", body)
+	}
+
+	server.Reply(http.MethodPost, "/scanners", http.StatusCreated, "")
+	if _, err := client.Create(&model.ScannerRegistrationReq{Name: "Trivy"}); err == nil {
+		t.Error("expected an error without a Location header")
+	}
+}
+
+func TestScannerSetDefault(t *testing.T) {
+	client, server := newTestScanners(t)
+	server.Reply(http.MethodPatch, "/scanners/c0ffee", http.StatusOK, "")
+
+	if err := client.SetDefault("c0ffee"); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"is_default":true}` {
+		t.Errorf("unexpected body %s", body)
+	}
+}
+
+func TestScannerMetadata(t *testing.T) {
+	client, server := newTestScanners(t)
+	server.Reply(http.MethodGet, "/scanners/c0ffee/metadata", http.StatusOK,
+		`{"scanner":{"name":"Trivy","vendor":"Aqua Security","version":"v0.50.1"},"capabilities":[{"type":"vulnerability","consumes_mime_types":["application/vnd.oci.image.manifest.v1+json"],"produces_mime_types":["application/vnd.security.vulnerability.report; version=1.1"]}],"properties":{"harbor.scanner-adapter/scanner-type":"os-package-vulnerability"}}`)
+
+	metadata, err := client.Metadata("c0ffee")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.Scanner == nil || metadata.Scanner.Name != "Trivy" || len(metadata.Capabilities) != 1 || metadata.Capabilities[0].Type != "vulnerability" {
+		t.Errorf("unexpected metadata %+v", metadata)
+	}
+}
+
+func TestScannerPing(t *testing.T) {
+	client, server := newTestScanners(t)
+	server.Reply(http.MethodPost, "/scanners/ping", http.StatusOK, "")
+
+	if err := client.Ping(&model.ScannerRegistrationReq{Name: "Trivy", URL: "http://trivy-adapter:8080"}); err != nil {
+		t.Fatal(err)
+	}
+	request := &model.ScannerRegistrationReq{}
+	server.Last().Decode(t, request)
+	if request.URL != "http://trivy-adapter:8080" {
+		t.Errorf("unexpected body %s", server.Last().Body)
+	}
+}
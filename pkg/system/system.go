@@ -0,0 +1,78 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package system
+
+import (
+	"context"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
+)
+
+// SystemInterface manages the system wide settings of Harbor.
+type SystemInterface interface {
+	CVEAllowlist() (result *model.CVEAllowlist, err error)
+	SetCVEAllowlist(allowlist *model.CVEAllowlist) (err error)
+	CVEAllowlistContext(ctx context.Context) (result *model.CVEAllowlist, err error)
+	SetCVEAllowlistContext(ctx context.Context, allowlist *model.CVEAllowlist) (err error)
+}
+
+var _ SystemInterface = &SystemClient{}
+
+type SystemClient struct {
+	restClient rest2.Interface
+}
+
+func NewSystemClient(restClient *rest2.Config) (*SystemClient, error) {
+	client, err := rest2.RESTClientFor(restClient)
+	if err != nil {
+		return nil, err
+	}
+	return &SystemClient{restClient: client}, nil
+}
+
+// CVEAllowlist returns the system CVE allowlist, applied to the projects reusing it.
+func (s *SystemClient) CVEAllowlist() (result *model.CVEAllowlist, err error) {
+	return s.CVEAllowlistContext(context.Background())
+}
+
+// CVEAllowlistContext is like CVEAllowlist but binds the request to ctx.
+func (s *SystemClient) CVEAllowlistContext(ctx context.Context) (result *model.CVEAllowlist, err error) {
+	result = &model.CVEAllowlist{}
+	err = s.restClient.Get().
+		Context(ctx).
+		Resource("system").
+		Name("CVEAllowlist").
+		Do().
+		Into(result)
+	return
+}
+
+// SetCVEAllowlist replaces the system CVE allowlist.
+func (s *SystemClient) SetCVEAllowlist(allowlist *model.CVEAllowlist) (err error) {
+	return s.SetCVEAllowlistContext(context.Background(), allowlist)
+}
+
+// SetCVEAllowlistContext is like SetCVEAllowlist but binds the request to ctx.
+func (s *SystemClient) SetCVEAllowlistContext(ctx context.Context, allowlist *model.CVEAllowlist) (err error) {
+	return s.restClient.Put().
+		Context(ctx).
+		Resource("system").
+		Name("CVEAllowlist").
+		Body(allowlist).
+		Do().
+		Error()
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package system
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	"github.com/TimeBye/go-harbor/pkg/rest/resttest"
+)
+
+func newTestClient(t *testing.T) (*SystemClient, *resttest.Server) {
+	server := resttest.NewServer(t)
+	client, err := NewSystemClient(server.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server
+}
+
+func TestSystemCVEAllowlist(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodGet, "/system/CVEAllowlist", http.StatusOK,
+		`{"id":1,"project_id":0,"expires_at":1712534400,"items":[{"cve_id":"CVE-2021-44228"}],"creation_time":"2024-01-02T03:04:05Z","update_time":"2024-01-02T03:04:05Z"}`)
+
+	allowlist, err := client.CVEAllowlist()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expiry, ok := allowlist.Expiry(); !ok || expiry.Unix() != 1712534400 || !allowlist.Contains("CVE-2021-44228") {
+		t.Errorf("unexpected allowlist %+v", allowlist)
+	}
+}
+
+func TestSystemSetCVEAllowlist(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodPut, "/system/CVEAllowlist", http.StatusOK, "")
+
+	allowlist := &model.CVEAllowlist{}
+	allowlist.AddUntil(time.Unix(1712534400, 0), "CVE-2021-44228", "CVE-2022-22965")
+	if err := client.SetCVEAllowlist(allowlist); err != nil {
+		t.Fatal(err)
+	}
+	body := map[string]json.RawMessage{}
+	server.Last().Decode(t, &body)
+	if items := string(body["items"]); items != `[{"cve_id":"CVE-2021-44228"},{"cve_id":"CVE-2022-22965"}]` {
+		t.Errorf("unexpected items %s", items)
+	}
+	if expiresAt := string(body["expires_at"]); expiresAt != "1712534400" {
+		t.Errorf("unexpected expires_at %s", expiresAt)
+	}
+
+	// Without expiry the allowlist never expires.
+	allowlist.SetExpiry(time.Time{})
+	if err := client.SetCVEAllowlist(allowlist); err != nil {
+		t.Fatal(err)
+	}
+	body = map[string]json.RawMessage{}
+	server.Last().Decode(t, &body)
+	if expiresAt, ok := body["expires_at"]; ok {
+		t.Errorf("expected no expires_at, got %s", expiresAt)
+	}
+}
@@ -16,16 +16,44 @@ See the License for the specific language governing permissions and
 package user
 
 import (
+	"context"
+	"strconv"
+
 	"github.com/TimeBye/go-harbor/pkg/model"
 	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
 	"github.com/goharbor/harbor/src/common/models"
 )
 
-// UsersInterface holds the methods that discover server-supported API groups,
-// versions and resources.
+// UsersInterface manages the local users of Harbor. Users are identified by their
+// ID, passed as a string to Get and Delete.
 type UsersInterface interface {
+	Get(name string) (result *models.User, err error)
+	List(query *model.Query) (results *[]models.User, err error)
+	ListPager(ctx context.Context, query *model.Query) *rest2.Pager[models.User]
+	ListAll(query *model.Query) (results *[]models.User, err error)
+	Delete(name string) (err error)
+	Create(user *model.UserCreationReq) (id int64, err error)
+	Update(id int64, profile *model.UserProfile) (err error)
+	SetPassword(id int64, password *model.PasswordReq) (err error)
+	SetSysAdmin(id int64, sysAdmin bool) (err error)
+	Current() (result *models.User, err error)
+	CurrentPermissions(scope string, relative bool) (results *[]model.Permission, err error)
+	Search(username string) (results *[]model.UserSearchResult, err error)
+	GetContext(ctx context.Context, name string) (result *models.User, err error)
+	ListContext(ctx context.Context, query *model.Query) (results *[]models.User, err error)
+	ListAllContext(ctx context.Context, query *model.Query) (results *[]models.User, err error)
+	DeleteContext(ctx context.Context, name string) (err error)
+	CreateContext(ctx context.Context, user *model.UserCreationReq) (id int64, err error)
+	UpdateContext(ctx context.Context, id int64, profile *model.UserProfile) (err error)
+	SetPasswordContext(ctx context.Context, id int64, password *model.PasswordReq) (err error)
+	SetSysAdminContext(ctx context.Context, id int64, sysAdmin bool) (err error)
+	CurrentContext(ctx context.Context) (result *models.User, err error)
+	CurrentPermissionsContext(ctx context.Context, scope string, relative bool) (results *[]model.Permission, err error)
+	SearchContext(ctx context.Context, username string) (results *[]model.UserSearchResult, err error)
 }
 
+var _ UsersInterface = &UsersClient{}
+
 type UsersClient struct {
 	restClient rest2.Interface
 }
@@ -39,8 +67,14 @@ func NewUsersClient(restClient *rest2.Config) (*UsersClient, error) {
 }
 
 func (u *UsersClient) Get(name string) (result *models.User, err error) {
+	return u.GetContext(context.Background(), name)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (u *UsersClient) GetContext(ctx context.Context, name string) (result *models.User, err error) {
 	result = &models.User{}
 	err = u.restClient.Get().
+		Context(ctx).
 		Resource("users").
 		Name(name).
 		Do().
@@ -49,8 +83,14 @@ func (u *UsersClient) Get(name string) (result *models.User, err error) {
 }
 
 func (u *UsersClient) List(query *model.Query) (results *[]models.User, err error) {
+	return u.ListContext(context.Background(), query)
+}
+
+// ListContext is like List but binds the request to ctx.
+func (u *UsersClient) ListContext(ctx context.Context, query *model.Query) (results *[]models.User, err error) {
 	results = &[]models.User{}
 	err = u.restClient.List().
+		Context(ctx).
 		Resource("users").
 		Params(*query).
 		Do().
@@ -58,10 +98,155 @@ func (u *UsersClient) List(query *model.Query) (results *[]models.User, err erro
 	return
 }
 
+// ListPager returns a Pager walking every user matching query.
+func (u *UsersClient) ListPager(ctx context.Context, query *model.Query) *rest2.Pager[models.User] {
+	return rest2.NewPager[models.User](ctx, func() *rest2.Request {
+		return u.restClient.List().
+			Resource("users").
+			Params(*query)
+	})
+}
+
+// ListAll returns every user matching query, walking all pages.
+func (u *UsersClient) ListAll(query *model.Query) (results *[]models.User, err error) {
+	return u.ListAllContext(context.Background(), query)
+}
+
+// ListAllContext is like ListAll but binds the requests to ctx.
+func (u *UsersClient) ListAllContext(ctx context.Context, query *model.Query) (results *[]models.User, err error) {
+	items, err := u.ListPager(ctx, query).All()
+	return &items, err
+}
+
 func (u *UsersClient) Delete(name string) (err error) {
+	return u.DeleteContext(context.Background(), name)
+}
+
+// DeleteContext is like Delete but binds the request to ctx.
+func (u *UsersClient) DeleteContext(ctx context.Context, name string) (err error) {
 	return u.restClient.Delete().
+		Context(ctx).
 		Resource("users").
 		Name(name).
 		Do().
 		Error()
 }
+
+// Create creates a local user and returns its ID.
+func (u *UsersClient) Create(user *model.UserCreationReq) (id int64, err error) {
+	return u.CreateContext(context.Background(), user)
+}
+
+// CreateContext is like Create but binds the request to ctx.
+func (u *UsersClient) CreateContext(ctx context.Context, user *model.UserCreationReq) (id int64, err error) {
+	return u.restClient.Post().
+		Context(ctx).
+		Resource("users").
+		Body(user).
+		Do().
+		CreatedID()
+}
+
+// Update changes the email, real name and comment of the user id.
+func (u *UsersClient) Update(id int64, profile *model.UserProfile) (err error) {
+	return u.UpdateContext(context.Background(), id, profile)
+}
+
+// UpdateContext is like Update but binds the request to ctx.
+func (u *UsersClient) UpdateContext(ctx context.Context, id int64, profile *model.UserProfile) (err error) {
+	return u.restClient.Put().
+		Context(ctx).
+		Resource("users").
+		Name(strconv.FormatInt(id, 10)).
+		Body(profile).
+		Do().
+		Error()
+}
+
+// SetPassword changes the password of the user id.
+func (u *UsersClient) SetPassword(id int64, password *model.PasswordReq) (err error) {
+	return u.SetPasswordContext(context.Background(), id, password)
+}
+
+// SetPasswordContext is like SetPassword but binds the request to ctx.
+func (u *UsersClient) SetPasswordContext(ctx context.Context, id int64, password *model.PasswordReq) (err error) {
+	return u.restClient.Put().
+		Context(ctx).
+		Resource("users").
+		Name(strconv.FormatInt(id, 10)).
+		Suffix("password").
+		Body(password).
+		Do().
+		Error()
+}
+
+// SetSysAdmin grants or revokes the system admin role of the user id.
+func (u *UsersClient) SetSysAdmin(id int64, sysAdmin bool) (err error) {
+	return u.SetSysAdminContext(context.Background(), id, sysAdmin)
+}
+
+// SetSysAdminContext is like SetSysAdmin but binds the request to ctx.
+func (u *UsersClient) SetSysAdminContext(ctx context.Context, id int64, sysAdmin bool) (err error) {
+	return u.restClient.Put().
+		Context(ctx).
+		Resource("users").
+		Name(strconv.FormatInt(id, 10)).
+		Suffix("sysadmin").
+		Body(&model.SysAdminFlag{SysAdminFlag: sysAdmin}).
+		Do().
+		Error()
+}
+
+// Current returns the user the client is authenticated as.
+func (u *UsersClient) Current() (result *models.User, err error) {
+	return u.CurrentContext(context.Background())
+}
+
+// CurrentContext is like Current but binds the request to ctx.
+func (u *UsersClient) CurrentContext(ctx context.Context) (result *models.User, err error) {
+	result = &models.User{}
+	err = u.restClient.Get().
+		Context(ctx).
+		Resource("users").
+		Name("current").
+		Do().
+		Into(result)
+	return
+}
+
+// CurrentPermissions lists the permissions of the current user on scope, e.g.
+// "/project/1". With relative the resources are returned relative to scope.
+func (u *UsersClient) CurrentPermissions(scope string, relative bool) (results *[]model.Permission, err error) {
+	return u.CurrentPermissionsContext(context.Background(), scope, relative)
+}
+
+// CurrentPermissionsContext is like CurrentPermissions but binds the request to ctx.
+func (u *UsersClient) CurrentPermissionsContext(ctx context.Context, scope string, relative bool) (results *[]model.Permission, err error) {
+	results = &[]model.Permission{}
+	err = u.restClient.Get().
+		Context(ctx).
+		Resource("users").
+		Name("current").
+		Suffix("permissions").
+		Param("scope", scope).
+		Param("relative", strconv.FormatBool(relative)).
+		Do().
+		Into(results)
+	return
+}
+
+// Search returns every user whose name matches username, walking all pages.
+func (u *UsersClient) Search(username string) (results *[]model.UserSearchResult, err error) {
+	return u.SearchContext(context.Background(), username)
+}
+
+// SearchContext is like Search but binds the requests to ctx.
+func (u *UsersClient) SearchContext(ctx context.Context, username string) (results *[]model.UserSearchResult, err error) {
+	items, err := rest2.NewPager[model.UserSearchResult](ctx, func() *rest2.Request {
+		return u.restClient.Get().
+			Resource("users").
+			Name("search").
+			Param("username", username)
+	}).All()
+	return &items, err
+}
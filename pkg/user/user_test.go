@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package user
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	"github.com/TimeBye/go-harbor/pkg/rest/resttest"
+)
+
+func newTestClient(t *testing.T) (*UsersClient, *resttest.Server) {
+	server := resttest.NewServer(t)
+	client, err := NewUsersClient(server.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server
+}
+
+func TestUserCreate(t *testing.T) {
+	client, server := newTestClient(t)
+	server.ReplyCreated(http.MethodPost, "/users", "5")
+
+	id, err := client.Create(&model.UserCreationReq{Username: "alice", Email: "alice@example.com", Realname: "Alice", Password: "Passw0rd"})
+	if err != nil || id != 5 {
+		t.Fatalf("unexpected id %d: %v", id, err)
+	}
+	if body := string(server.Last().Body); body != `{"username":"alice","email":"alice@example.com","realname":"Alice","password":"Passw0rd"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+}
+
+func TestUserSetPassword(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodPut, "/users/5/password", http.StatusOK, "")
+
+	if err := client.SetPassword(5, &model.PasswordReq{OldPassword: "Passw0rd", NewPassword: "N3wPassw0rd"}); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"old_password":"Passw0rd","new_password":"N3wPassw0rd"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+
+	// A system admin resets the password without the old one.
+	if err := client.SetPassword(5, &model.PasswordReq{NewPassword: "N3wPassw0rd"}); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"new_password":"N3wPassw0rd"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+}
+
+func TestUserSetSysAdmin(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodPut, "/users/5/sysadmin", http.StatusOK, "")
+
+	for _, sysAdmin := range []bool{true, false} {
+		if err := client.SetSysAdmin(5, sysAdmin); err != nil {
+			t.Fatal(err)
+		}
+		flag := model.SysAdminFlag{SysAdminFlag: !sysAdmin}
+		server.Last().Decode(t, &flag)
+		if flag.SysAdminFlag != sysAdmin {
+			t.Errorf("expected sysadmin_flag %t in %s", sysAdmin, server.Last().Body)
+		}
+	}
+}
+
+func TestUserUpdate(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodPut, "/users/5", http.StatusOK, "")
+
+	if err := client.Update(5, &model.UserProfile{Email: "alice@example.org"}); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"email":"alice@example.org"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+}
+
+func TestUserCurrentPermissions(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodGet, "/users/current/permissions", http.StatusOK, `[{"resource":"repository","action":"pull"}]`)
+
+	permissions, err := client.CurrentPermissions("/project/1", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*permissions) != 1 || (*permissions)[0].Action != "pull" {
+		t.Errorf("unexpected permissions %+v", *permissions)
+	}
+	if query := server.Last().Query; query.Get("scope") != "/project/1" || query.Get("relative") != "true" {
+		t.Errorf("unexpected query %v", query)
+	}
+}
+
+func TestUserSearch(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodGet, "/users/search", http.StatusOK, `[{"user_id":5,"username":"alice"}]`)
+
+	users, err := client.Search("ali")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*users) != 1 || (*users)[0].UserID != 5 {
+		t.Errorf("unexpected users %+v", *users)
+	}
+	if username := server.Last().Query.Get("username"); username != "ali" {
+		t.Errorf("unexpected username %q", username)
+	}
+}
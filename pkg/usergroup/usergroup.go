@@ -0,0 +1,181 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package usergroup
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	rest2 "github.com/TimeBye/go-harbor/pkg/rest"
+)
+
+// UserGroupsInterface manages the LDAP, HTTP and OIDC user groups known to Harbor.
+// Groups are given access to projects through the project members client.
+type UserGroupsInterface interface {
+	Get(id int64) (result *model.UserGroup, err error)
+	List(query *ListOptions) (results *[]model.UserGroup, err error)
+	ListPager(ctx context.Context, query *ListOptions) *rest2.Pager[model.UserGroup]
+	ListAll(query *ListOptions) (results *[]model.UserGroup, err error)
+	Search(groupName string) (results *[]model.UserGroupSearchResult, err error)
+	Create(group *model.UserGroup) (id int64, err error)
+	Update(id int64, groupName string) (err error)
+	Delete(id int64) (err error)
+	GetContext(ctx context.Context, id int64) (result *model.UserGroup, err error)
+	ListContext(ctx context.Context, query *ListOptions) (results *[]model.UserGroup, err error)
+	ListAllContext(ctx context.Context, query *ListOptions) (results *[]model.UserGroup, err error)
+	SearchContext(ctx context.Context, groupName string) (results *[]model.UserGroupSearchResult, err error)
+	CreateContext(ctx context.Context, group *model.UserGroup) (id int64, err error)
+	UpdateContext(ctx context.Context, id int64, groupName string) (err error)
+	DeleteContext(ctx context.Context, id int64) (err error)
+}
+
+var _ UserGroupsInterface = &UserGroupsClient{}
+
+// ListOptions filters the user groups to list.
+type ListOptions struct {
+	*model.Query
+	// LDAPGroupDN The LDAP group DN
+	LDAPGroupDN string `json:"ldap_group_dn,omitempty"`
+	// GroupName The group name, fuzzy matched
+	GroupName string `json:"group_name,omitempty"`
+}
+
+type UserGroupsClient struct {
+	restClient rest2.Interface
+}
+
+func NewUserGroupsClient(restClient *rest2.Config) (*UserGroupsClient, error) {
+	client, err := rest2.RESTClientFor(restClient)
+	if err != nil {
+		return nil, err
+	}
+	return &UserGroupsClient{restClient: client}, nil
+}
+
+func (u *UserGroupsClient) Get(id int64) (result *model.UserGroup, err error) {
+	return u.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but binds the request to ctx.
+func (u *UserGroupsClient) GetContext(ctx context.Context, id int64) (result *model.UserGroup, err error) {
+	result = &model.UserGroup{}
+	err = u.restClient.Get().
+		Context(ctx).
+		Resource("usergroups").
+		Name(strconv.FormatInt(id, 10)).
+		Do().
+		Into(result)
+	return
+}
+
+func (u *UserGroupsClient) List(query *ListOptions) (results *[]model.UserGroup, err error) {
+	return u.ListContext(context.Background(), query)
+}
+
+// ListContext is like List but binds the request to ctx.
+func (u *UserGroupsClient) ListContext(ctx context.Context, query *ListOptions) (results *[]model.UserGroup, err error) {
+	results = &[]model.UserGroup{}
+	err = u.restClient.List().
+		Context(ctx).
+		Resource("usergroups").
+		Params(*query).
+		Do().
+		Into(results)
+	return
+}
+
+// ListPager returns a Pager walking every user group matching query.
+func (u *UserGroupsClient) ListPager(ctx context.Context, query *ListOptions) *rest2.Pager[model.UserGroup] {
+	return rest2.NewPager[model.UserGroup](ctx, func() *rest2.Request {
+		return u.restClient.List().
+			Resource("usergroups").
+			Params(*query)
+	})
+}
+
+// ListAll returns every user group matching query, walking all pages.
+func (u *UserGroupsClient) ListAll(query *ListOptions) (results *[]model.UserGroup, err error) {
+	return u.ListAllContext(context.Background(), query)
+}
+
+// ListAllContext is like ListAll but binds the requests to ctx.
+func (u *UserGroupsClient) ListAllContext(ctx context.Context, query *ListOptions) (results *[]model.UserGroup, err error) {
+	items, err := u.ListPager(ctx, query).All()
+	return &items, err
+}
+
+// Search returns every user group whose name matches groupName, walking all pages.
+func (u *UserGroupsClient) Search(groupName string) (results *[]model.UserGroupSearchResult, err error) {
+	return u.SearchContext(context.Background(), groupName)
+}
+
+// SearchContext is like Search but binds the requests to ctx.
+func (u *UserGroupsClient) SearchContext(ctx context.Context, groupName string) (results *[]model.UserGroupSearchResult, err error) {
+	items, err := rest2.NewPager[model.UserGroupSearchResult](ctx, func() *rest2.Request {
+		return u.restClient.Get().
+			Resource("usergroups").
+			Name("search").
+			Param("groupname", groupName)
+	}).All()
+	return &items, err
+}
+
+// Create creates a user group and returns its ID. LDAP groups are identified by
+// their DN, HTTP and OIDC groups by their name.
+func (u *UserGroupsClient) Create(group *model.UserGroup) (id int64, err error) {
+	return u.CreateContext(context.Background(), group)
+}
+
+// CreateContext is like Create but binds the request to ctx.
+func (u *UserGroupsClient) CreateContext(ctx context.Context, group *model.UserGroup) (id int64, err error) {
+	return u.restClient.Post().
+		Context(ctx).
+		Resource("usergroups").
+		Body(group).
+		Do().
+		CreatedID()
+}
+
+// Update renames the user group id, the only change Harbor allows.
+func (u *UserGroupsClient) Update(id int64, groupName string) (err error) {
+	return u.UpdateContext(context.Background(), id, groupName)
+}
+
+// UpdateContext is like Update but binds the request to ctx.
+func (u *UserGroupsClient) UpdateContext(ctx context.Context, id int64, groupName string) (err error) {
+	return u.restClient.Put().
+		Context(ctx).
+		Resource("usergroups").
+		Name(strconv.FormatInt(id, 10)).
+		Body(&model.UserGroup{GroupName: groupName}).
+		Do().
+		Error()
+}
+
+func (u *UserGroupsClient) Delete(id int64) (err error) {
+	return u.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext is like Delete but binds the request to ctx.
+func (u *UserGroupsClient) DeleteContext(ctx context.Context, id int64) (err error) {
+	return u.restClient.Delete().
+		Context(ctx).
+		Resource("usergroups").
+		Name(strconv.FormatInt(id, 10)).
+		Do().
+		Error()
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2020 The go-harbor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+*/
+
+package usergroup
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/TimeBye/go-harbor/pkg/model"
+	"github.com/TimeBye/go-harbor/pkg/rest/resttest"
+)
+
+func newTestClient(t *testing.T) (*UserGroupsClient, *resttest.Server) {
+	server := resttest.NewServer(t)
+	client, err := NewUserGroupsClient(server.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server
+}
+
+func TestUserGroupCreate(t *testing.T) {
+	client, server := newTestClient(t)
+	server.ReplyCreated(http.MethodPost, "/usergroups", "9")
+
+	for _, c := range []struct {
+		group *model.UserGroup
+		body  string
+	}{
+		{
+			group: &model.UserGroup{GroupName: "devs", GroupType: model.UserGroupTypeLDAP, LDAPGroupDN: "cn=devs,ou=groups,dc=example,dc=com"},
+			body:  `{"group_name":"devs","group_type":1,"ldap_group_dn":"cn=devs,ou=groups,dc=example,dc=com"}`,
+		},
+		{
+			group: &model.UserGroup{GroupName: "ops", GroupType: model.UserGroupTypeHTTP},
+			body:  `{"group_name":"ops","group_type":2}`,
+		},
+		{
+			group: &model.UserGroup{GroupName: "qa", GroupType: model.UserGroupTypeOIDC},
+			body:  `{"group_name":"qa","group_type":3}`,
+		},
+	} {
+		id, err := client.Create(c.group)
+		if err != nil || id != 9 {
+			t.Fatalf("unexpected id %d: %v", id, err)
+		}
+		if body := string(server.Last().Body); body != c.body {
+			t.Errorf("%s group: unexpected body %s", c.group.GroupType, body)
+		}
+	}
+}
+
+func TestUserGroupGetAndUpdate(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodGet, "/usergroups/9", http.StatusOK, `{"id":9,"group_name":"qa","group_type":3}`)
+	server.Reply(http.MethodPut, "/usergroups/9", http.StatusOK, "")
+	server.Reply(http.MethodDelete, "/usergroups/9", http.StatusOK, "")
+
+	group, err := client.Get(9)
+	if err != nil || group.GroupType != model.UserGroupTypeOIDC || group.GroupType.String() != "oidc" {
+		t.Errorf("unexpected group %+v: %v", group, err)
+	}
+	if err := client.Update(9, "testers"); err != nil {
+		t.Fatal(err)
+	}
+	if body := string(server.Last().Body); body != `{"group_name":"testers"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+	if err := client.Delete(9); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUserGroupList(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodGet, "/usergroups", http.StatusOK, `[{"id":9,"group_name":"devs","group_type":1,"ldap_group_dn":"cn=devs,ou=groups,dc=example,dc=com"}]`)
+
+	groups, err := client.List(&ListOptions{Query: &model.Query{}, GroupName: "dev"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*groups) != 1 || (*groups)[0].LDAPGroupDN == "" {
+		t.Errorf("unexpected groups %+v", *groups)
+	}
+	if name := server.Last().Query.Get("group_name"); name != "dev" {
+		t.Errorf("unexpected group_name %q", name)
+	}
+}
+
+func TestUserGroupSearch(t *testing.T) {
+	client, server := newTestClient(t)
+	server.Reply(http.MethodGet, "/usergroups/search", http.StatusOK, `[{"id":9,"group_name":"devs","group_type":2}]`)
+
+	groups, err := client.Search("dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*groups) != 1 || (*groups)[0].GroupType != model.UserGroupTypeHTTP {
+		t.Errorf("unexpected groups %+v", *groups)
+	}
+	query := server.Last().Query
+	if query.Get("groupname") != "dev" {
+		t.Errorf("unexpected query %v", query)
+	}
+	if _, ok := query["group_name"]; ok {
+		t.Errorf("search takes groupname, not group_name: %v", query)
+	}
+}